@@ -208,4 +208,114 @@ func ConvertDateSimpleHandler(c *gin.Context) {
 	}
 	
 	responseSuccess(c, result)
-} 
\ No newline at end of file
+} 
+
+// 中文日期转换为YYYY-MM-DD
+func ParseChineseDateHandler(c *gin.Context) {
+	var req model.ParseChineseDateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ParseChineseDate(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "日期解析失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 金额转换为人民币大写
+func AmountToChineseHandler(c *gin.Context) {
+	var req model.AmountToChineseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ConvertAmountToChinese(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "金额转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 人民币大写金额解析为数字
+func ChineseToAmountHandler(c *gin.Context) {
+	var req model.ChineseNumberParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ParseChineseAmount(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "金额解析失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 整数转换为中文数字
+func NumberToChineseHandler(c *gin.Context) {
+	var req model.NumberToChineseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ConvertNumberToChinese(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "数字转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 中文数字解析为整数
+func ChineseToNumberHandler(c *gin.Context) {
+	var req model.ChineseNumberParseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ParseChineseNumber(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "数字解析失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
@@ -4,7 +4,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/renoz/toolbox-api/model"
 	"github.com/renoz/toolbox-api/service"
+	"net/http"
 	"strconv"
+	"strings"
 )
 
 // 工作日计算
@@ -32,10 +34,13 @@ func WorkdayRangeHandler(c *gin.Context) {
 // 获取当前时间
 func CurrentTimeHandler(c *gin.Context) {
 	var req struct {
-		Format       string `json:"format" form:"format"`
-		Timezone     string `json:"timezone" form:"timezone"`
-		TzOffset     *int   `json:"tz_offset" form:"tz_offset"`
-		CustomFormat string `json:"custom_format" form:"custom_format"`
+		Format       string      `json:"format" form:"format"`
+		Timezone     string      `json:"timezone" form:"timezone"`
+		TzOffset     interface{} `json:"tz_offset" form:"tz_offset"`
+		CustomFormat string      `json:"custom_format" form:"custom_format"`
+		FormatStyle  string      `json:"format_style" form:"format_style"`
+		Locale       string      `json:"locale" form:"locale"`
+		model.RelativeTimeOptions
 	}
 
 	// 绑定JSON参数
@@ -45,7 +50,7 @@ func CurrentTimeHandler(c *gin.Context) {
 	}
 
 	// 调用服务函数获取当前时间
-	response, err := service.GetCurrentTime(req.Format, req.Timezone, req.TzOffset, req.CustomFormat)
+	response, err := service.GetCurrentTime(req.Format, req.Timezone, req.TzOffset, req.CustomFormat, req.FormatStyle, req.Locale, req.RelativeTimeOptions)
 	if err != nil {
 		if e, ok := err.(*model.ErrorResponse); ok {
 			responseError(c, e.Code, e.Message)
@@ -62,9 +67,14 @@ func CurrentTimeHandler(c *gin.Context) {
 func IsWeekendHandler(c *gin.Context) {
 	// 解析参数
 	dateStr := c.DefaultQuery("date", "")
+	opts := model.WorkdayOptions{
+		RestDayPattern: c.Query("rest_day_pattern"),
+		Calendar:       c.Query("calendar"),
+		CalendarID:     c.Query("calendar_id"),
+	}
 	
 	// 调用服务处理
-	result, err := service.CheckIsWeekend(dateStr)
+	result, err := service.CheckIsWeekend(dateStr, opts)
 	if err != nil {
 		if e, ok := err.(*model.ErrorResponse); ok {
 			responseError(c, e.Code, e.Message)
@@ -137,14 +147,10 @@ func TimezoneInfoHandler(c *gin.Context) {
 	// 解析参数
 	timezone := c.DefaultQuery("timezone", "")
 	
-	// 解析时区偏移量
-	var tzOffset *int
-	tzOffsetStr := c.Query("tz_offset")
-	if tzOffsetStr != "" {
-		offset, err := strconv.Atoi(tzOffsetStr)
-		if err == nil {
-			tzOffset = &offset
-		}
+	// 解析时区偏移量，支持小时数、分钟数或+05:30格式
+	var tzOffset interface{}
+	if tzOffsetStr := c.Query("tz_offset"); tzOffsetStr != "" {
+		tzOffset = tzOffsetStr
 	}
 	
 	// 调用服务处理
@@ -159,4 +165,734 @@ func TimezoneInfoHandler(c *gin.Context) {
 	}
 	
 	responseSuccess(c, result)
-} 
\ No newline at end of file
+} 
+
+// 获取法定节假日安排
+func HolidaysHandler(c *gin.Context) {
+	// 解析参数
+	calendar := c.DefaultQuery("calendar", "cn")
+	
+	// 解析年份，默认当前年份
+	year := 0
+	yearStr := c.Query("year")
+	if yearStr != "" {
+		y, err := strconv.Atoi(yearStr)
+		if err != nil {
+			responseError(c, 4001, "year参数必须是整数")
+			return
+		}
+		year = y
+	}
+	
+	// 调用服务处理
+	result, err := service.GetHolidays(calendar, year)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取节假日安排失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+
+// 获取自定义日历列表
+func ListCalendarsHandler(c *gin.Context) {
+	result, err := service.ListCalendars()
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取日历列表失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 获取自定义日历详情
+func GetCalendarHandler(c *gin.Context) {
+	result, err := service.GetCalendar(c.Param("id"))
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 创建自定义日历
+func CreateCalendarHandler(c *gin.Context) {
+	var req model.CalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.CreateCalendar(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "创建日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 更新自定义日历
+func UpdateCalendarHandler(c *gin.Context) {
+	var req model.CalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.UpdateCalendar(c.Param("id"), req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "更新日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 删除自定义日历
+func DeleteCalendarHandler(c *gin.Context) {
+	id := c.Param("id")
+	if err := service.DeleteCalendar(id); err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "删除日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, gin.H{
+		"id":      id,
+		"deleted": true,
+	})
+}
+
+// 导入自定义日历
+func ImportCalendarHandler(c *gin.Context) {
+	var req model.CalendarImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ImportCalendar(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "导入日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 导出自定义日历，直接返回文件内容
+func ExportCalendarHandler(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	
+	// 调用服务处理
+	data, contentType, filename, err := service.ExportCalendar(c.Param("id"), format)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "导出日历失败: "+err.Error())
+		}
+		return
+	}
+	
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// 工作日偏移计算
+func OffsetWorkdaysHandler(c *gin.Context) {
+	var req model.WorkdayOffsetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.OffsetWorkdays(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "计算工作日偏移时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 获取下一个工作日
+func NextWorkdayHandler(c *gin.Context) {
+	var req model.AdjacentWorkdayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.NextWorkday(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取下一个工作日时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 获取上一个工作日
+func PreviousWorkdayHandler(c *gin.Context) {
+	var req model.AdjacentWorkdayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.PreviousWorkday(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取上一个工作日时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 工作时间截止时间计算
+func BusinessDeadlineHandler(c *gin.Context) {
+	var req model.BusinessDeadlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.CalculateBusinessDeadline(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "计算截止时间时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 工作时长统计
+func BusinessElapsedHandler(c *gin.Context) {
+	var req model.BusinessElapsedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.CalculateBusinessElapsed(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "统计工作时长时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 获取二十四节气
+func SolarTermsHandler(c *gin.Context) {
+	// 解析年份，默认当前年份
+	year := 0
+	yearStr := c.Query("year")
+	if yearStr != "" {
+		y, err := strconv.Atoi(yearStr)
+		if err != nil {
+			responseError(c, 4001, "year参数必须是整数")
+			return
+		}
+		year = y
+	}
+	
+	// 调用服务处理
+	result, err := service.GetSolarTerms(year)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "获取节气信息失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 公历转农历
+func SolarToLunarHandler(c *gin.Context) {
+	var req model.SolarToLunarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ConvertSolarToLunar(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "农历转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 农历转公历
+func LunarToSolarHandler(c *gin.Context) {
+	var req model.LunarToSolarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ConvertLunarToSolar(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "农历转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 计算时间差
+func TimeDiffHandler(c *gin.Context) {
+	var req model.TimeDiffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.CalculateTimeDiff(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "计算时间差时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 时间加减ISO-8601时长
+func TimeAddHandler(c *gin.Context) {
+	var req model.TimeAddRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.AddTimeDuration(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "时间加减时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 解析Cron表达式并计算下次触发时间
+func CronHandler(c *gin.Context) {
+	var req model.CronRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.GetCronSchedule(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "解析Cron表达式时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 展开RRULE重复规则
+func RRuleHandler(c *gin.Context) {
+	var req model.RRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ExpandRRule(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "展开重复规则时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 查询IANA时区列表
+func TimezonesHandler(c *gin.Context) {
+	// 解析筛选参数
+	query := c.Query("q")
+	country := c.Query("country")
+	offset := c.Query("offset")
+	
+	// 调用服务处理
+	result, err := service.SearchTimezones(query, country, offset)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "查询时区列表失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 查询时区的夏令时转换
+func TimezoneTransitionsHandler(c *gin.Context) {
+	timezone := c.Query("timezone")
+	
+	// 解析年份和数量
+	year := 0
+	if yearStr := c.Query("year"); yearStr != "" {
+		y, err := strconv.Atoi(yearStr)
+		if err != nil {
+			responseError(c, 4001, "year参数必须是整数")
+			return
+		}
+		year = y
+	}
+	count := 0
+	if countStr := c.Query("count"); countStr != "" {
+		n, err := strconv.Atoi(countStr)
+		if err != nil {
+			responseError(c, 4001, "count参数必须是整数")
+			return
+		}
+		count = n
+	}
+	
+	// 调用服务处理
+	result, err := service.GetTimezoneTransitions(timezone, year, count)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "查询时区转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 自然语言时间解析
+func NaturalTimeHandler(c *gin.Context) {
+	var req model.NaturalTimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.ParseNaturalTime(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "解析自然语言时间时发生错误: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 日历网格处理器
+func CalendarGridHandler(c *gin.Context) {
+	var req model.CalendarGridRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.GetCalendarGrid(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "生成日历网格失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 查询周期起止日期
+func PeriodHandler(c *gin.Context) {
+	req := model.PeriodRequest{
+		Type: c.DefaultQuery("type", ""),
+		Date: c.DefaultQuery("date", ""),
+	}
+	
+	// 解析整数参数
+	intParams := map[string]*int{
+		"year":               &req.Year,
+		"number":             &req.Number,
+		"fiscal_start_month": &req.FiscalStartMonth,
+	}
+	for name, target := range intParams {
+		if valueStr := c.Query(name); valueStr != "" {
+			value, err := strconv.Atoi(valueStr)
+			if err != nil {
+				responseError(c, 4001, name+"参数必须是整数")
+				return
+			}
+			*target = value
+		}
+	}
+	
+	// 调用服务处理
+	result, err := service.GetPeriod(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "查询周期失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 日期范围枚举处理器
+func DateRangeHandler(c *gin.Context) {
+	var req model.DateRangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.EnumerateDateRange(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "枚举日期范围失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 生成排班表，format为csv时返回CSV文件
+func ShiftScheduleHandler(c *gin.Context) {
+	var req model.ShiftScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.GenerateShiftSchedule(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "生成排班表失败: "+err.Error())
+		}
+		return
+	}
+	
+	if strings.ToLower(req.Format) != "csv" {
+		responseSuccess(c, result)
+		return
+	}
+	
+	data, contentType, filename, err := service.ExportShiftScheduleCSV(result)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "导出排班表失败: "+err.Error())
+		}
+		return
+	}
+	
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// 计算年龄、生肖和星座
+func AgeHandler(c *gin.Context) {
+	// 解析参数
+	req := model.AgeRequest{
+		BirthDate:     c.DefaultQuery("birth_date", ""),
+		CalendarType:  c.DefaultQuery("calendar_type", "solar"),
+		IsLeapMonth:   c.DefaultQuery("is_leap_month", "false") == "true",
+		ReferenceDate: c.DefaultQuery("reference_date", ""),
+	}
+	if req.BirthDate == "" {
+		responseError(c, 4001, "参数验证错误: birth_date不能为空")
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.CalculateAge(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "计算年龄失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 批量时间转换
+func BatchTimeConvertHandler(c *gin.Context) {
+	var req model.BatchTimeConvertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.BatchConvertTime(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "批量时间转换失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 时间取整处理器
+func TruncateTimeHandler(c *gin.Context) {
+	var req model.TimeTruncateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.TruncateTime(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "时间取整失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
+
+// 多时区会议规划处理器
+func MeetingPlannerHandler(c *gin.Context) {
+	var req model.MeetingPlannerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responseError(c, 4001, "参数验证错误: "+err.Error())
+		return
+	}
+	
+	// 调用服务处理
+	result, err := service.PlanMeeting(req)
+	if err != nil {
+		if e, ok := err.(*model.ErrorResponse); ok {
+			responseError(c, e.Code, e.Message)
+		} else {
+			responseError(c, 9000, "会议规划失败: "+err.Error())
+		}
+		return
+	}
+	
+	responseSuccess(c, result)
+}
@@ -80,6 +80,54 @@ type ConvertDateSimpleRequest struct {
 	DateStr string `json:"date_str" binding:"required"`
 }
 
+// 中文日期解析请求
+type ParseChineseDateRequest struct {
+	DateStr string `json:"date_str" binding:"required"` // 如 贰零贰肆年零壹月零壹拾日、二〇二四年一月十日
+}
+
+// 金额转换为人民币大写请求
+type AmountToChineseRequest struct {
+	Amount interface{} `json:"amount" binding:"required"` // 数字或数字字符串，如 12345.67、"-1,000.5"
+}
+
+// 金额转换为人民币大写响应
+type AmountToChineseResponse struct {
+	Original  string `json:"original"`
+	Amount    string `json:"amount"` // 四舍五入到分的金额
+	Converted string `json:"converted"`
+}
+
+// 中文数字或大写金额解析请求
+type ChineseNumberParseRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// 大写金额解析响应
+type ChineseToAmountResponse struct {
+	Original string  `json:"original"`
+	Amount   string  `json:"amount"`
+	Value    float64 `json:"value"`
+}
+
+// 整数转换为中文数字请求
+type NumberToChineseRequest struct {
+	Number *int64 `json:"number" binding:"required"`
+	Style  string `json:"style"` // simple（默认，一万二千）或 upper（壹万贰仟）
+}
+
+// 整数转换为中文数字响应
+type NumberToChineseResponse struct {
+	Number    int64  `json:"number"`
+	Style     string `json:"style"`
+	Converted string `json:"converted"`
+}
+
+// 中文数字解析响应
+type ChineseToNumberResponse struct {
+	Original string `json:"original"`
+	Number   int64  `json:"number"`
+}
+
 // 随机数生成响应
 type RandomIntegerResponse struct {
 	Numbers []int `json:"numbers"`
@@ -88,22 +136,356 @@ type RandomIntegerResponse struct {
 	Max     int   `json:"max"`
 }
 
-// 工作日计算请求
-type WorkdayRangeRequest struct {
-	StartDate      string   `json:"start_date" binding:"required"`
-	EndDate        string   `json:"end_date" binding:"required"`
+// 工作日判定参数
+type WorkdayOptions struct {
 	RestDayPattern string   `json:"rest_day_pattern"`
 	ExcludeDates   []string `json:"exclude_dates"`
 	AddDates       []string `json:"add_dates"`
+	Calendar       string   `json:"calendar"`    // 节假日日历，如 cn 表示中国法定节假日
+	CalendarID     string   `json:"calendar_id"` // 自定义日历ID
+}
+
+// 工作日计算请求
+type WorkdayRangeRequest struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+	WorkdayOptions
 }
 
 // 工作日计算响应
 type WorkdayRangeResponse struct {
-	TotalDays   int      `json:"total_days"`
-	Workdays    int      `json:"workdays"`
-	Restdays    int      `json:"restdays"`
-	WorkdayList []string `json:"workday_list"`
-	RestdayList []string `json:"restday_list"`
+	TotalDays            int             `json:"total_days"`
+	Workdays             int             `json:"workdays"`
+	Restdays             int             `json:"restdays"`
+	WorkdayList          []string        `json:"workday_list"`
+	RestdayList          []string        `json:"restday_list"`
+	RestdayDetails       []RestdayDetail `json:"restday_details"`
+	Calendar             string          `json:"calendar,omitempty"`
+	CalendarID           string          `json:"calendar_id,omitempty"`
+	CalendarVersion      string          `json:"calendar_version,omitempty"`
+	CalendarMissingYears []int           `json:"calendar_missing_years,omitempty"`
+}
+
+// 工作日偏移请求
+type WorkdayOffsetRequest struct {
+	StartDate string `json:"start_date"` // 默认今天
+	Days      int    `json:"days"`       // 正数向后，负数向前
+	WorkdayOptions
+}
+
+// 相邻工作日请求
+type AdjacentWorkdayRequest struct {
+	Date string `json:"date"` // 默认今天
+	WorkdayOptions
+}
+
+// 工作日偏移响应
+type WorkdayOffsetResponse struct {
+	StartDate            string          `json:"start_date"`
+	Days                 int             `json:"days"`
+	ResultDate           string          `json:"result_date"`
+	Weekday              int             `json:"weekday"`
+	WeekdayName          string          `json:"weekday_name"`
+	CalendarDays         int             `json:"calendar_days"` // 跨越的自然日数，向前为负数
+	SkippedRestdays      []RestdayDetail `json:"skipped_restdays"`
+	Calendar             string          `json:"calendar,omitempty"`
+	CalendarID           string          `json:"calendar_id,omitempty"`
+	CalendarVersion      string          `json:"calendar_version,omitempty"`
+	CalendarMissingYears []int           `json:"calendar_missing_years,omitempty"`
+}
+
+// 工作时间参数
+type BusinessHoursOptions struct {
+	WorkingHours []string    `json:"working_hours"` // 每日工作时段，如 ["09:00-12:00", "13:30-18:00"]，默认 09:00-18:00
+	Timezone     string      `json:"timezone"`
+	TzOffset     interface{} `json:"tz_offset"`
+	WorkdayOptions
+}
+
+// 工作时间截止时间请求
+type BusinessDeadlineRequest struct {
+	StartTime interface{} `json:"start_time"` // 默认当前时间
+	Hours     float64     `json:"hours"`
+	Minutes   int         `json:"minutes"`
+	BusinessHoursOptions
+}
+
+// 工作时间截止时间响应
+type BusinessDeadlineResponse struct {
+	StartTime         string       `json:"start_time"`
+	EffectiveStart    string       `json:"effective_start"` // 顺延到工作时段后实际开始计时的时间
+	Deadline          string       `json:"deadline"`
+	DeadlineTimestamp int64        `json:"deadline_timestamp"`
+	BusinessSeconds   int64        `json:"business_seconds"`
+	BusinessDuration  string       `json:"business_duration"`
+	CalendarDuration  string       `json:"calendar_duration"` // 开始到截止的自然时长
+	WorkingHours      []string     `json:"working_hours"`
+	Timezone          string       `json:"timezone"`
+	TimezoneInfo      TimezoneInfo `json:"timezone_info"`
+}
+
+// 工作时长统计请求
+type BusinessElapsedRequest struct {
+	StartTime interface{} `json:"start_time" binding:"required"`
+	EndTime   interface{} `json:"end_time"` // 默认当前时间
+	BusinessHoursOptions
+}
+
+// 工作时长统计响应
+type BusinessElapsedResponse struct {
+	StartTime        string       `json:"start_time"`
+	EndTime          string       `json:"end_time"`
+	BusinessSeconds  int64        `json:"business_seconds"`
+	BusinessHours    float64      `json:"business_hours"`
+	BusinessDuration string       `json:"business_duration"`
+	Workdays         int          `json:"workdays"` // 有工作时长的天数
+	WorkingHours     []string     `json:"working_hours"`
+	Timezone         string       `json:"timezone"`
+	TimezoneInfo     TimezoneInfo `json:"timezone_info"`
+}
+
+// 时间差计算请求
+type TimeDiffRequest struct {
+	StartTime interface{} `json:"start_time" binding:"required"`
+	EndTime   interface{} `json:"end_time"` // 默认当前时间
+	Timezone  string      `json:"timezone"`
+	TzOffset  interface{} `json:"tz_offset"`
+}
+
+// 时间差计算响应，结束时间早于开始时间时各字段为负数
+type TimeDiffResponse struct {
+	StartTime    string       `json:"start_time"`
+	EndTime      string       `json:"end_time"`
+	Negative     bool         `json:"negative"`
+	Years        int          `json:"years"`
+	Months       int          `json:"months"`
+	Days         int          `json:"days"`
+	Hours        int          `json:"hours"`
+	Minutes      int          `json:"minutes"`
+	Seconds      int          `json:"seconds"`
+	TotalMonths  int          `json:"total_months"` // 按日历计算的完整月数
+	TotalWeeks   float64      `json:"total_weeks"`
+	TotalDays    float64      `json:"total_days"`
+	TotalHours   float64      `json:"total_hours"`
+	TotalMinutes float64      `json:"total_minutes"`
+	TotalSeconds int64        `json:"total_seconds"`
+	ISODuration  string       `json:"iso_duration"`
+	Description  string       `json:"description"`
+	Timezone     string       `json:"timezone"`
+	TimezoneInfo TimezoneInfo `json:"timezone_info"`
+}
+
+// 时间加减请求
+type TimeAddRequest struct {
+	TimeInput    interface{} `json:"time_input"`                  // 默认当前时间
+	Duration     string      `json:"duration" binding:"required"` // ISO-8601时长，如 P1M2DT3H、-P1W
+	Operation    string      `json:"operation"`                   // add（默认）或 subtract
+	OutputFormat string      `json:"output_format"`
+	CustomFormat string      `json:"custom_format"`
+	Timezone     string      `json:"timezone"`
+	TzOffset     interface{} `json:"tz_offset"`
+}
+
+// 时间加减响应
+type TimeAddResponse struct {
+	Original     string       `json:"original"`
+	Duration     string       `json:"duration"`
+	Operation    string       `json:"operation"`
+	Result       string       `json:"result"`
+	ResultISO    string       `json:"result_iso"`
+	Timestamp    int64        `json:"timestamp"`
+	Timezone     string       `json:"timezone"`
+	TimezoneInfo TimezoneInfo `json:"timezone_info"`
+}
+
+// Cron表达式请求
+type CronRequest struct {
+	Expression   string      `json:"expression" binding:"required"` // 5字段、6字段（含秒）或@daily等宏
+	Count        int         `json:"count"`                         // 返回的触发次数，默认5，最大100
+	StartTime    interface{} `json:"start_time"`                    // 从该时间之后开始计算，默认当前时间
+	WorkdaysOnly bool        `json:"workdays_only"`                 // 仅在工作日触发
+	OutputFormat string      `json:"output_format"`
+	CustomFormat string      `json:"custom_format"`
+	Timezone     string      `json:"timezone"`
+	TzOffset     interface{} `json:"tz_offset"`
+	WorkdayOptions
+}
+
+// Cron触发时间
+type CronRunItem struct {
+	Time        string `json:"time"`
+	Timestamp   int64  `json:"timestamp"`
+	WeekdayName string `json:"weekday_name"`
+}
+
+// Cron表达式响应
+type CronResponse struct {
+	Expression    string        `json:"expression"`
+	Normalized    string        `json:"normalized"` // 宏展开后的表达式
+	Fields        []string      `json:"fields"`
+	HasSeconds    bool          `json:"has_seconds"`
+	DescriptionZh string        `json:"description_zh"`
+	DescriptionEn string        `json:"description_en"`
+	StartTime     string        `json:"start_time"`
+	NextRuns      []CronRunItem `json:"next_runs"`
+	WorkdaysOnly  bool          `json:"workdays_only"`
+	Timezone      string        `json:"timezone"`
+	TimezoneInfo  TimezoneInfo  `json:"timezone_info"`
+}
+
+// RRULE重复规则展开请求
+type RRuleRequest struct {
+	RRule        string        `json:"rrule" binding:"required"` // 如 FREQ=MONTHLY;BYDAY=-1FR，也可包含DTSTART、EXDATE行
+	DTStart      interface{}   `json:"dtstart"`                  // 默认当前时间
+	Until        interface{}   `json:"until"`                    // 覆盖规则中的UNTIL
+	Count        int           `json:"count"`                    // 覆盖规则中的COUNT
+	ExDates      []interface{} `json:"exdates"`                  // 排除的时间，仅日期时排除当天所有重复
+	Limit        int           `json:"limit"`                    // 最多返回的重复次数，默认100，最大1000
+	OutputFormat string        `json:"output_format"`
+	CustomFormat string        `json:"custom_format"`
+	Timezone     string        `json:"timezone"`
+	TzOffset     interface{}   `json:"tz_offset"`
+}
+
+// RRULE重复时间
+type RRuleOccurrence struct {
+	Time        string `json:"time"`
+	Timestamp   int64  `json:"timestamp"`
+	WeekdayName string `json:"weekday_name"`
+}
+
+// RRULE重复规则展开响应
+type RRuleResponse struct {
+	RRule        string            `json:"rrule"` // 规范化后的规则
+	DTStart      string            `json:"dtstart"`
+	Until        string            `json:"until,omitempty"`
+	Count        int               `json:"count,omitempty"`
+	Occurrences  []RRuleOccurrence `json:"occurrences"`
+	Total        int               `json:"total"`
+	Excluded     int               `json:"excluded"`  // 被EXDATE排除的次数
+	Truncated    bool              `json:"truncated"` // 达到limit后截断
+	Timezone     string            `json:"timezone"`
+	TimezoneInfo TimezoneInfo      `json:"timezone_info"`
+}
+
+// 休息日明细
+type RestdayDetail struct {
+	Date   string `json:"date"`
+	Type   string `json:"type"`   // weekend/holiday/exclude
+	Reason string `json:"reason"` // 周末、节日名称或自定义排除
+}
+
+// 节假日放假安排
+type HolidayItem struct {
+	Name     string   `json:"name"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Days     int      `json:"days"`
+	Workdays []string `json:"workdays"` // 调休上班日期
+}
+
+// 节假日列表响应
+type HolidayListResponse struct {
+	Calendar string        `json:"calendar"`
+	Version  string        `json:"version"`
+	Year     int           `json:"year"`
+	Holidays []HolidayItem `json:"holidays"`
+}
+
+// 自定义日历中的日期
+type CalendarDate struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// 自定义日历（公司日历、地区日历等）
+type Calendar struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	BaseCalendar   string         `json:"base_calendar"`    // 继承的内置日历，如 cn
+	RestDayPattern string         `json:"rest_day_pattern"` // 为空时使用请求中的休息日模式
+	Holidays       []CalendarDate `json:"holidays"`         // 休息日
+	Workdays       []CalendarDate `json:"workdays"`         // 上班日（如调休）
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+}
+
+// 自定义日历创建/更新请求
+type CalendarRequest struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name" binding:"required"`
+	Description    string         `json:"description"`
+	BaseCalendar   string         `json:"base_calendar"`
+	RestDayPattern string         `json:"rest_day_pattern"`
+	Holidays       []CalendarDate `json:"holidays"`
+	Workdays       []CalendarDate `json:"workdays"`
+}
+
+// 自定义日历导入请求
+type CalendarImportRequest struct {
+	Format         string `json:"format"` // json 或 ics，默认json
+	Content        string `json:"content" binding:"required"`
+	ID             string `json:"id"`   // 覆盖导入内容中的ID，已存在时替换原日历
+	Name           string `json:"name"` // 覆盖导入内容中的名称
+	BaseCalendar   string `json:"base_calendar"`
+	RestDayPattern string `json:"rest_day_pattern"`
+}
+
+// 自定义日历列表响应
+type CalendarListResponse struct {
+	Calendars []Calendar `json:"calendars"`
+	Count     int        `json:"count"`
+}
+
+// 公历转农历请求
+type SolarToLunarRequest struct {
+	Date string `json:"date"` // YYYY-MM-DD，默认今天
+}
+
+// 农历转公历请求
+type LunarToSolarRequest struct {
+	Year        int  `json:"year" binding:"required"`
+	Month       int  `json:"month" binding:"required"`
+	Day         int  `json:"day" binding:"required"`
+	IsLeapMonth bool `json:"is_leap_month"`
+}
+
+// 农历日期响应
+type LunarDateResponse struct {
+	SolarDate   string   `json:"solar_date"`
+	Weekday     int      `json:"weekday"`
+	WeekdayName string   `json:"weekday_name"`
+	LunarYear   int      `json:"lunar_year"`
+	LunarMonth  int      `json:"lunar_month"`
+	LunarDay    int      `json:"lunar_day"`
+	IsLeapMonth bool     `json:"is_leap_month"`
+	LeapMonth   int      `json:"leap_month"` // 当年闰月，0表示无闰月
+	MonthDays   int      `json:"month_days"` // 当月天数
+	MonthName   string   `json:"month_name"`
+	DayName     string   `json:"day_name"`
+	LunarText   string   `json:"lunar_text"`
+	GanzhiYear  string   `json:"ganzhi_year"`
+	GanzhiMonth string   `json:"ganzhi_month"`
+	GanzhiDay   string   `json:"ganzhi_day"`
+	Zodiac      string   `json:"zodiac"`
+	SolarTerm   string   `json:"solar_term"` // 当天为节气时返回节气名称
+	Festivals   []string `json:"festivals"`
+}
+
+// 节气信息
+type SolarTermItem struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Date  string `json:"date"`
+	Time  string `json:"time"` // 交节时刻（北京时间）
+}
+
+// 二十四节气响应
+type SolarTermsResponse struct {
+	Year     int             `json:"year"`
+	Timezone string          `json:"timezone"`
+	Terms    []SolarTermItem `json:"terms"`
 }
 
 // 时区信息响应
@@ -112,16 +494,29 @@ type TimezoneInfo struct {
 	OffsetHours   int    `json:"offset_hours"`
 	OffsetMinutes int    `json:"offset_minutes"`
 	OffsetSeconds int    `json:"offset_seconds"`
+	Offset        string `json:"offset"` // 如 +05:30
 	Description   string `json:"description"`
 }
 
+// 相对时间格式参数，output_format为relative时生效
+type RelativeTimeOptions struct {
+	ReferenceTime interface{} `json:"reference_time" form:"reference_time"` // 参考时间，默认当前时间
+	Language      string      `json:"language" form:"language"`             // zh（默认）或 en
+	Granularity   string      `json:"granularity" form:"granularity"`       // 最小单位：second、minute（默认）、hour、day
+}
+
 // 时间转换请求
 type TimeConvertRequest struct {
 	TimeInput     interface{} `json:"time_input" binding:"required"`
+	InputFormat   string      `json:"input_format"`   // Python strptime格式，如 %d.%m.%Y %H:%M
+	InputTimezone string      `json:"input_timezone"` // 不带偏移的输入所在时区，默认UTC
 	OutputFormat  string      `json:"output_format"`
 	Timezone      string      `json:"timezone"`
-	TzOffset      *int        `json:"tz_offset"`
+	TzOffset      interface{} `json:"tz_offset"` // 小时数（可为小数，如5.5）、分钟数（如330）或"+05:30"
 	CustomFormat  string      `json:"custom_format"`
+	FormatStyle   string      `json:"format_style"` // custom_format的风格：python（默认）、moment、java或go
+	Locale        string      `json:"locale"`       // 月份、星期及上下午名称的语言区域：zh-CN、zh-TW、en-US或ja-JP
+	RelativeTimeOptions
 }
 
 // 时间转换响应
@@ -132,6 +527,36 @@ type TimeConvertResponse struct {
 	TimezoneInfo TimezoneInfo `json:"timezone_info"`
 }
 
+// 批量时间转换请求，items中每项为time_input值或完整的时间转换请求对象，对象中未指定的字段使用外层的公共参数
+type BatchTimeConvertRequest struct {
+	Items         []interface{} `json:"items" binding:"required,min=1"`
+	InputFormat   string        `json:"input_format"`
+	InputTimezone string        `json:"input_timezone"`
+	OutputFormat  string        `json:"output_format"`
+	Timezone      string        `json:"timezone"`
+	TzOffset      interface{}   `json:"tz_offset"`
+	CustomFormat  string        `json:"custom_format"`
+	FormatStyle   string        `json:"format_style"`
+	Locale        string        `json:"locale"`
+	RelativeTimeOptions
+}
+
+// 批量时间转换中单项的结果，失败时只返回error
+type BatchTimeConvertItem struct {
+	Index   int                  `json:"index"`
+	Success bool                 `json:"success"`
+	Result  *TimeConvertResponse `json:"result,omitempty"`
+	Error   *ErrorResponse       `json:"error,omitempty"`
+}
+
+// 批量时间转换响应
+type BatchTimeConvertResponse struct {
+	Total     int                    `json:"total"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Results   []BatchTimeConvertItem `json:"results"`
+}
+
 // 当前时间响应
 type CurrentTimeResponse struct {
 	CurrentTime  string      `json:"current_time"`
@@ -155,6 +580,10 @@ type IsWeekendResponse struct {
 	IsWeekend   bool   `json:"is_weekend"`
 	Weekday     int    `json:"weekday"`
 	WeekdayName string `json:"weekday_name"`
+	IsRestDay   bool   `json:"is_rest_day"`         // 结合节假日日历后是否休息
+	RestType    string `json:"rest_type,omitempty"` // weekend/holiday/exclude
+	Reason      string `json:"reason,omitempty"`    // 休息或调休上班原因
+	CalendarID  string `json:"calendar_id,omitempty"`
 }
 
 // 周数信息响应
@@ -174,4 +603,406 @@ type TimezoneInfoResponse struct {
 	TimezoneInfo     TimezoneInfo `json:"timezone_info"`
 	CurrentTime      string       `json:"current_time"`
 	AvailableOffsets []int        `json:"available_offsets"`
+	SupportedOffsets []string     `json:"supported_offsets"` // 当前使用中的全部偏移，含非整小时偏移
+}
+
+// IANA时区条目
+type TimezoneItem struct {
+	Name          string   `json:"name"`
+	CountryCode   string   `json:"country_code"`
+	CountryName   string   `json:"country_name"`
+	CountryNameZh string   `json:"country_name_zh"`
+	Comment       string   `json:"comment"`
+	Offset        string   `json:"offset"` // 当前UTC偏移，如 +08:00
+	OffsetSeconds int      `json:"offset_seconds"`
+	Description   string   `json:"description"`
+	Abbreviation  string   `json:"abbreviation"`
+	IsDST         bool     `json:"is_dst"`       // 当前是否处于夏令时
+	ObservesDST   bool     `json:"observes_dst"` // 今年是否实行夏令时
+	Aliases       []string `json:"aliases,omitempty"`
+}
+
+// IANA时区列表响应
+type TimezoneListResponse struct {
+	Total     int            `json:"total"`
+	Version   string         `json:"version"`
+	Timezones []TimezoneItem `json:"timezones"`
+}
+
+// 时区时段（两次转换之间）
+type TimezonePeriod struct {
+	Abbreviation  string `json:"abbreviation"`
+	Offset        string `json:"offset"`
+	OffsetSeconds int    `json:"offset_seconds"`
+	IsDST         bool   `json:"is_dst"`
+	Start         string `json:"start,omitempty"`
+	End           string `json:"end,omitempty"`
+}
+
+// 时区偏移转换（夏令时开始/结束等）
+type TimezoneTransition struct {
+	Time             string `json:"time"`
+	UTCTime          string `json:"utc_time"`
+	Timestamp        int64  `json:"timestamp"`
+	LocalTimeBefore  string `json:"local_time_before"` // 转换时刻按原偏移显示的时间
+	LocalTimeAfter   string `json:"local_time_after"`  // 转换时刻按新偏移显示的时间
+	FromOffset       string `json:"from_offset"`
+	ToOffset         string `json:"to_offset"`
+	FromAbbreviation string `json:"from_abbreviation"`
+	ToAbbreviation   string `json:"to_abbreviation"`
+	IsDST            bool   `json:"is_dst"`
+	Type             string `json:"type"` // dst_start、dst_end、offset_change
+	Description      string `json:"description"`
+}
+
+// 时区转换查询响应
+type TimezoneTransitionsResponse struct {
+	Timezone     string               `json:"timezone"`
+	TimezoneInfo TimezoneInfo         `json:"timezone_info"`
+	ObservesDST  bool                 `json:"observes_dst"`
+	Current      TimezonePeriod       `json:"current"`
+	Year         int                  `json:"year,omitempty"`
+	Transitions  []TimezoneTransition `json:"transitions,omitempty"` // 指定年份的全部转换
+	Past         []TimezoneTransition `json:"past,omitempty"`
+	Upcoming     []TimezoneTransition `json:"upcoming,omitempty"`
+}
+
+// 自然语言时间解析请求
+type NaturalTimeRequest struct {
+	Text          string      `json:"text" binding:"required"` // 如 下周三下午3点、3天后、明年春节、next friday at 3pm
+	ReferenceTime interface{} `json:"reference_time"`          // 参考时间，默认当前时间
+	OutputFormat  string      `json:"output_format"`
+	CustomFormat  string      `json:"custom_format"`
+	Timezone      string      `json:"timezone"`
+	TzOffset      interface{} `json:"tz_offset"`
+}
+
+// 自然语言时间解析响应
+type NaturalTimeResponse struct {
+	Text          string       `json:"text"`
+	Matched       string       `json:"matched"`  // 识别出的时间表达式
+	Start         int          `json:"start"`    // 匹配片段在原文中的起始字符位置（从0开始）
+	End           int          `json:"end"`      // 匹配片段在原文中的结束字符位置（不含）
+	Converted     string       `json:"converted"`
+	Date          string       `json:"date"`
+	Timestamp     int64        `json:"timestamp"`
+	HasTime       bool         `json:"has_time"` // 是否包含具体时刻，否则为当天零点
+	Confidence    float64      `json:"confidence"`
+	ReferenceTime string       `json:"reference_time"`
+	Timezone      string       `json:"timezone"`
+	TimezoneInfo  TimezoneInfo `json:"timezone_info"`
+}
+
+// 日历网格请求
+type CalendarGridRequest struct {
+	Year            int   `json:"year"`              // 默认今年
+	Month           int   `json:"month"`             // 1-12，为0时返回全年12个月
+	StartWithMonday *bool `json:"start_with_monday"` // 每周是否从周一开始，默认true
+	IncludeLunar    bool  `json:"include_lunar"`     // 是否附带农历信息
+	WorkdayOptions
+}
+
+// 日历网格中的农历信息
+type CalendarGridLunar struct {
+	MonthName   string   `json:"month_name"`
+	DayName     string   `json:"day_name"`
+	IsLeapMonth bool     `json:"is_leap_month"`
+	DisplayText string   `json:"display_text"` // 格子中显示的文字：节日 > 节气 > 初一显示月份 > 日期
+	SolarTerm   string   `json:"solar_term,omitempty"`
+	Festivals   []string `json:"festivals,omitempty"`
+}
+
+// 日历网格中的一天
+type CalendarGridDay struct {
+	Date        string             `json:"date"`
+	Day         int                `json:"day"`
+	Weekday     int                `json:"weekday"`
+	WeekdayName string             `json:"weekday_name"`
+	InMonth     bool               `json:"in_month"` // 是否属于当前月份，否则为前后月份的补位日期
+	IsRest      bool               `json:"is_rest"`
+	RestType    string             `json:"rest_type,omitempty"`
+	Reason      string             `json:"reason,omitempty"`
+	Lunar       *CalendarGridLunar `json:"lunar,omitempty"`
+}
+
+// 日历网格中的一周
+type CalendarGridWeek struct {
+	WeekInMonth int               `json:"week_in_month"` // 月内周数
+	WeekInYear  int               `json:"week_in_year"`  // 年内周数
+	ISOYear     int               `json:"iso_year"`
+	ISOWeek     int               `json:"iso_week"`
+	Days        []CalendarGridDay `json:"days"`
+}
+
+// 日历网格中的一个月
+type CalendarGridMonth struct {
+	Year     int                `json:"year"`
+	Month    int                `json:"month"`
+	Days     int                `json:"days"` // 当月天数
+	Workdays int                `json:"workdays"`
+	Restdays int                `json:"restdays"`
+	Weeks    []CalendarGridWeek `json:"weeks"`
+}
+
+// 日历网格响应
+type CalendarGridResponse struct {
+	Year                 int                 `json:"year"`
+	Month                int                 `json:"month,omitempty"`
+	StartDay             string              `json:"start_day"`       // 周一或周日
+	WeekdayHeaders       []string            `json:"weekday_headers"` // 按周起始日排列的星期名称
+	Months               []CalendarGridMonth `json:"months"`
+	Calendar             string              `json:"calendar,omitempty"`
+	CalendarID           string              `json:"calendar_id,omitempty"`
+	CalendarVersion      string              `json:"calendar_version,omitempty"`
+	CalendarMissingYears []int               `json:"calendar_missing_years,omitempty"`
+}
+
+// 周期范围查询请求，指定date时返回包含该日期的周期，否则按year和number定位
+type PeriodRequest struct {
+	Type             string `json:"type"`               // week（ISO周）、month、quarter、half_year、year、fiscal_year
+	Year             int    `json:"year"`               // 周期所在年份，week为ISO年份，fiscal_year为起始年份
+	Number           int    `json:"number"`             // 周数、月份、季度或半年序号
+	Date             string `json:"date"`               // YYYY-MM-DD
+	FiscalStartMonth int    `json:"fiscal_start_month"` // 财年起始月份，默认1
+}
+
+// 周期范围查询响应
+type PeriodResponse struct {
+	Type             string `json:"type"`
+	Year             int    `json:"year"`
+	Number           int    `json:"number,omitempty"`
+	Label            string `json:"label"` // 如 2024-W05、2024-02、2024-Q1、2024-H1、FY2024
+	StartDate        string `json:"start_date"`
+	EndDate          string `json:"end_date"`
+	Days             int    `json:"days"`
+	FiscalStartMonth int    `json:"fiscal_start_month,omitempty"`
+	Date             string `json:"date,omitempty"`
+	DayOfPeriod      *int   `json:"day_of_period,omitempty"`  // 指定日期是周期内第几天
+	DaysRemaining    *int   `json:"days_remaining,omitempty"` // 指定日期之后周期内剩余天数
+	PreviousStart    string `json:"previous_start"`           // 上一周期开始日期
+	NextStart        string `json:"next_start"`               // 下一周期开始日期
+}
+
+// 日期范围枚举请求
+type DateRangeRequest struct {
+	Start        interface{} `json:"start" binding:"required"` // 开始时间（包含）
+	End          interface{} `json:"end" binding:"required"`   // 结束时间（包含），仅日期时包含当天
+	Step         string      `json:"step"`                     // day（默认）、week、month、quarter、year或ISO-8601时长，如 PT6H、P2W
+	WorkdaysOnly bool        `json:"workdays_only"`            // 仅保留工作日
+	Weekdays     []int       `json:"weekdays"`                 // 仅保留指定星期，0为周日
+	OutputFormat string      `json:"output_format"`            // 默认按天及以上步长为date_only，否则为datetime
+	CustomFormat string      `json:"custom_format"`
+	Timezone     string      `json:"timezone"`
+	TzOffset     interface{} `json:"tz_offset"`
+	Page         int         `json:"page"`      // 页码，从1开始
+	PageSize     int         `json:"page_size"` // 每页数量，默认100，最大1000
+	WorkdayOptions
+}
+
+// 日期范围中的一项
+type DateRangeItem struct {
+	Index       int    `json:"index"` // 在筛选后结果中的序号，从0开始
+	Value       string `json:"value"`
+	Timestamp   int64  `json:"timestamp"`
+	Weekday     int    `json:"weekday"`
+	WeekdayName string `json:"weekday_name"`
+	IsRest      bool   `json:"is_rest"`
+}
+
+// 日期范围枚举响应
+type DateRangeResponse struct {
+	Start        string          `json:"start"`
+	End          string          `json:"end"`
+	Step         string          `json:"step"` // 规范化的ISO-8601步长
+	Total        int             `json:"total"`
+	Page         int             `json:"page"`
+	PageSize     int             `json:"page_size"`
+	TotalPages   int             `json:"total_pages"`
+	HasMore      bool            `json:"has_more"`
+	Items        []DateRangeItem `json:"items"`
+	Timezone     string          `json:"timezone"`
+	TimezoneInfo TimezoneInfo    `json:"timezone_info"`
+}
+
+// 排班生成请求，休息日判定与工作日计算相同，但未指定休息日模式和自定义日历时默认全年无休
+type ShiftScheduleRequest struct {
+	People      []string `json:"people" binding:"required,min=1"`
+	Pattern     []string `json:"pattern" binding:"required,min=1"` // 轮换班次，如 ["早", "中", "晚", "休"]
+	StartDate   string   `json:"start_date" binding:"required"`
+	EndDate     string   `json:"end_date" binding:"required"`
+	Offsets     []int    `json:"offsets"`       // 每人在轮换中的起始位置，默认按人员顺序依次错开
+	RestShift   string   `json:"rest_shift"`    // 表示休息的班次名称，默认"休"
+	PauseOnRest bool     `json:"pause_on_rest"` // 休息日轮换是否暂停，默认继续轮换
+	Format      string   `json:"format"`        // json（默认）或 csv
+	WorkdayOptions
+}
+
+// 某人当天的班次
+type ShiftAssignment struct {
+	Person string `json:"person"`
+	Shift  string `json:"shift"`
+}
+
+// 排班表中的一天
+type ShiftScheduleDay struct {
+	Date        string              `json:"date"`
+	Weekday     int                 `json:"weekday"`
+	WeekdayName string              `json:"weekday_name"`
+	IsRest      bool                `json:"is_rest"` // 休息日所有人休息
+	RestType    string              `json:"rest_type,omitempty"`
+	Reason      string              `json:"reason,omitempty"`
+	Assignments []ShiftAssignment   `json:"assignments"`
+	ByShift     map[string][]string `json:"by_shift"` // 班次 -> 人员
+}
+
+// 每人排班统计
+type ShiftPersonTotal struct {
+	Person   string         `json:"person"`
+	WorkDays int            `json:"work_days"`
+	RestDays int            `json:"rest_days"`
+	Shifts   map[string]int `json:"shifts"` // 班次 -> 天数
+}
+
+// 排班生成响应
+type ShiftScheduleResponse struct {
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	TotalDays int                `json:"total_days"`
+	Pattern   []string           `json:"pattern"`
+	RestShift string             `json:"rest_shift"`
+	Days      []ShiftScheduleDay `json:"days"`
+	Totals    []ShiftPersonTotal `json:"totals"`
+}
+
+// 年龄计算请求
+type AgeRequest struct {
+	BirthDate     string `json:"birth_date" binding:"required"` // YYYY-MM-DD，农历生日时为农历年月日
+	CalendarType  string `json:"calendar_type"`                 // solar（默认）或 lunar
+	IsLeapMonth   bool   `json:"is_leap_month"`                 // 农历生日是否为闰月
+	ReferenceDate string `json:"reference_date"`                // 参考日期，默认今天
+}
+
+// 年龄计算响应
+type AgeResponse struct {
+	BirthDate             string `json:"birth_date"`                // 公历出生日期
+	LunarBirthDate        string `json:"lunar_birth_date,omitempty"` // 农历出生日期，如 庚午年三月初八
+	CalendarType          string `json:"calendar_type"`
+	ReferenceDate         string `json:"reference_date"`
+	Age                   int    `json:"age"`                   // 周岁
+	NominalAge            int    `json:"nominal_age,omitempty"` // 虚岁，按农历年计算
+	AgeDescription        string `json:"age_description"`       // 如 33岁5个月12天
+	DaysLived             int    `json:"days_lived"`
+	NextBirthday          string `json:"next_birthday"` // 下次生日的公历日期，农历生日按农历计算
+	DaysUntilNextBirthday int    `json:"days_until_next_birthday"`
+	NextBirthdayAge       int    `json:"next_birthday_age"`
+	IsBirthdayToday       bool   `json:"is_birthday_today"`
+	Zodiac                string `json:"zodiac,omitempty"` // 生肖，以农历正月初一为界
+	Constellation         string `json:"constellation"`    // 星座
+	ConstellationRange    string `json:"constellation_range"`
+}
+
+// 时间取整请求，指定times时同时按取整单位分桶统计
+type TimeTruncateRequest struct {
+	TimeInput       interface{}   `json:"time_input"`        // 默认当前时间
+	Times           []interface{} `json:"times"`             // 批量取整并分桶，最多1000个
+	Unit            string        `json:"unit"`              // second、minute、hour、day、week、month、quarter、year，默认minute
+	Interval        int           `json:"interval"`          // 单位倍数，如unit为minute时5表示5分钟，默认1
+	Mode            string        `json:"mode"`              // floor（默认）、ceil、round
+	StartWithMonday *bool         `json:"start_with_monday"` // 按周取整时每周是否从周一开始，默认true
+	OutputFormat    string        `json:"output_format"`     // 默认iso
+	CustomFormat    string        `json:"custom_format"`
+	Timezone        string        `json:"timezone"`
+	TzOffset        interface{}   `json:"tz_offset"`
+}
+
+// 单个时间的取整结果
+type TimeTruncateItem struct {
+	Original    string `json:"original"`
+	Result      string `json:"result"`
+	Timestamp   int64  `json:"timestamp"`
+	BucketStart string `json:"bucket_start"` // 所在区间的开始时间
+	BucketEnd   string `json:"bucket_end"`   // 所在区间的结束时间（不含）
+	WeekNumber  int    `json:"week_number,omitempty"`
+}
+
+// 分桶统计
+type TimeBucket struct {
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Timestamp int64    `json:"timestamp"`
+	Count     int      `json:"count"`
+	Items     []string `json:"items"` // 落入该区间的原始时间
+}
+
+// 时间取整响应
+type TimeTruncateResponse struct {
+	Unit         string             `json:"unit"`
+	Interval     int                `json:"interval"`
+	Mode         string             `json:"mode"`
+	Result       *TimeTruncateItem  `json:"result,omitempty"`
+	Results      []TimeTruncateItem `json:"results,omitempty"`
+	Buckets      []TimeBucket       `json:"buckets,omitempty"` // 按开始时间升序
+	Timezone     string             `json:"timezone"`
+	TimezoneInfo TimezoneInfo       `json:"timezone_info"`
+}
+
+// 会议规划中的参会时区
+type MeetingZone struct {
+	Name string `json:"name"` // 显示名称，默认为时区名称
+	BusinessHoursOptions
+}
+
+// 多时区会议规划请求，日期以第一个时区为准
+type MeetingPlannerRequest struct {
+	Zones       []MeetingZone `json:"zones" binding:"required,min=1"`
+	Date        string        `json:"date"`         // YYYY-MM-DD，默认第一个时区的今天
+	SlotMinutes int           `json:"slot_minutes"` // 时间表粒度（分钟），需整除60，默认30
+	MinDuration int           `json:"min_duration"` // 重叠时段的最短时长（分钟），默认等于slot_minutes
+}
+
+// 参会时区信息
+type MeetingZoneInfo struct {
+	Name         string   `json:"name"`
+	Timezone     string   `json:"timezone"`
+	Offset       string   `json:"offset"` // 当天开始时的UTC偏移
+	IsDST        bool     `json:"is_dst"`
+	WorkingHours []string `json:"working_hours"`
+}
+
+// 某时区的本地时间
+type MeetingLocalTime struct {
+	Name      string `json:"name"`
+	Start     string `json:"start"`
+	End       string `json:"end,omitempty"`
+	Offset    string `json:"offset"`
+	IsWorking bool   `json:"is_working"` // 是否在该时区的工作时段内
+}
+
+// 所有时区共同的工作时段
+type MeetingWindow struct {
+	Start           string             `json:"start"`
+	End             string             `json:"end"`
+	StartTimestamp  int64              `json:"start_timestamp"`
+	EndTimestamp    int64              `json:"end_timestamp"`
+	DurationMinutes int                `json:"duration_minutes"`
+	Local           []MeetingLocalTime `json:"local"`
+}
+
+// 时间表中的一个时段
+type MeetingSlot struct {
+	Start          string             `json:"start"`
+	Timestamp      int64              `json:"timestamp"`
+	AvailableCount int                `json:"available_count"` // 处于工作时段的时区数
+	AllAvailable   bool               `json:"all_available"`
+	Local          []MeetingLocalTime `json:"local"`
+}
+
+// 多时区会议规划响应
+type MeetingPlannerResponse struct {
+	Date        string            `json:"date"`
+	Timezone    string            `json:"timezone"` // 日期和时间表所依据的时区
+	SlotMinutes int               `json:"slot_minutes"`
+	Zones       []MeetingZoneInfo `json:"zones"`
+	Windows     []MeetingWindow   `json:"windows"`
+	Slots       []MeetingSlot     `json:"slots"`
 }
\ No newline at end of file
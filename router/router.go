@@ -59,6 +59,11 @@ func SetupRouter() *gin.Engine {
 			stringGroup.POST("/extract-initials", controller.ExtractInitialsHandler)
 			stringGroup.POST("/convert-date", controller.ConvertDateHandler)
 			stringGroup.POST("/convert-date-simple", controller.ConvertDateSimpleHandler)
+			stringGroup.POST("/parse-chinese-date", controller.ParseChineseDateHandler)
+			stringGroup.POST("/amount-to-chinese", controller.AmountToChineseHandler)
+			stringGroup.POST("/chinese-to-amount", controller.ChineseToAmountHandler)
+			stringGroup.POST("/number-to-chinese", controller.NumberToChineseHandler)
+			stringGroup.POST("/chinese-to-number", controller.ChineseToNumberHandler)
 			
 			// 添加非POST方法的处理，为每个HTTP方法单独设置处理函数
 			notSupportedHandler := func(c *gin.Context) {
@@ -101,10 +106,43 @@ func SetupRouter() *gin.Engine {
 			timeGroup.POST("/workday-range", controller.WorkdayRangeHandler)
 			timeGroup.POST("/current", controller.CurrentTimeHandler)
 			timeGroup.POST("/convert", controller.TimeConvertHandler)
+			timeGroup.POST("/workday-offset", controller.OffsetWorkdaysHandler)
+			timeGroup.POST("/next-workday", controller.NextWorkdayHandler)
+			timeGroup.POST("/previous-workday", controller.PreviousWorkdayHandler)
+			timeGroup.POST("/business-hours/deadline", controller.BusinessDeadlineHandler)
+			timeGroup.POST("/business-hours/elapsed", controller.BusinessElapsedHandler)
+			timeGroup.POST("/lunar/from-solar", controller.SolarToLunarHandler)
+			timeGroup.POST("/lunar/to-solar", controller.LunarToSolarHandler)
+			timeGroup.POST("/diff", controller.TimeDiffHandler)
+			timeGroup.POST("/add", controller.TimeAddHandler)
+			timeGroup.POST("/cron", controller.CronHandler)
+			timeGroup.POST("/rrule", controller.RRuleHandler)
+			timeGroup.POST("/parse-natural", controller.NaturalTimeHandler)
+			timeGroup.POST("/calendar-grid", controller.CalendarGridHandler)
+			timeGroup.POST("/range", controller.DateRangeHandler)
+			timeGroup.POST("/shift-schedule", controller.ShiftScheduleHandler)
+			timeGroup.POST("/convert/batch", controller.BatchTimeConvertHandler)
+			timeGroup.POST("/truncate", controller.TruncateTimeHandler)
+			timeGroup.POST("/meeting-planner", controller.MeetingPlannerHandler)
 			
 			timeGroup.GET("/is-weekend", controller.IsWeekendHandler)
 			timeGroup.GET("/week-number", controller.WeekNumberHandler)
 			timeGroup.GET("/timezone-info", controller.TimezoneInfoHandler)
+			timeGroup.GET("/holidays", controller.HolidaysHandler)
+			timeGroup.GET("/lunar/solar-terms", controller.SolarTermsHandler)
+			timeGroup.GET("/timezones", controller.TimezonesHandler)
+			timeGroup.GET("/timezones/transitions", controller.TimezoneTransitionsHandler)
+			timeGroup.GET("/period", controller.PeriodHandler)
+			timeGroup.GET("/age", controller.AgeHandler)
+			
+			// 自定义日历管理
+			timeGroup.GET("/calendars", controller.ListCalendarsHandler)
+			timeGroup.POST("/calendars", controller.CreateCalendarHandler)
+			timeGroup.POST("/calendars/import", controller.ImportCalendarHandler)
+			timeGroup.GET("/calendars/:id", controller.GetCalendarHandler)
+			timeGroup.PUT("/calendars/:id", controller.UpdateCalendarHandler)
+			timeGroup.DELETE("/calendars/:id", controller.DeleteCalendarHandler)
+			timeGroup.GET("/calendars/:id/export", controller.ExportCalendarHandler)
 			
 			// 为POST接口添加方法不支持的处理
 			postNotSupportedHandler := func(c *gin.Context) {
@@ -134,6 +172,114 @@ func SetupRouter() *gin.Engine {
 			timeGroup.PATCH("/convert", postNotSupportedHandler)
 			timeGroup.OPTIONS("/convert", postNotSupportedHandler)
 			
+			timeGroup.GET("/workday-offset", postNotSupportedHandler)
+			timeGroup.PUT("/workday-offset", postNotSupportedHandler)
+			timeGroup.DELETE("/workday-offset", postNotSupportedHandler)
+			timeGroup.PATCH("/workday-offset", postNotSupportedHandler)
+			timeGroup.OPTIONS("/workday-offset", postNotSupportedHandler)
+			
+			timeGroup.GET("/next-workday", postNotSupportedHandler)
+			timeGroup.PUT("/next-workday", postNotSupportedHandler)
+			timeGroup.DELETE("/next-workday", postNotSupportedHandler)
+			timeGroup.PATCH("/next-workday", postNotSupportedHandler)
+			timeGroup.OPTIONS("/next-workday", postNotSupportedHandler)
+			
+			timeGroup.GET("/previous-workday", postNotSupportedHandler)
+			timeGroup.PUT("/previous-workday", postNotSupportedHandler)
+			timeGroup.DELETE("/previous-workday", postNotSupportedHandler)
+			timeGroup.PATCH("/previous-workday", postNotSupportedHandler)
+			timeGroup.OPTIONS("/previous-workday", postNotSupportedHandler)
+			
+			timeGroup.GET("/business-hours/deadline", postNotSupportedHandler)
+			timeGroup.PUT("/business-hours/deadline", postNotSupportedHandler)
+			timeGroup.DELETE("/business-hours/deadline", postNotSupportedHandler)
+			timeGroup.PATCH("/business-hours/deadline", postNotSupportedHandler)
+			timeGroup.OPTIONS("/business-hours/deadline", postNotSupportedHandler)
+			
+			timeGroup.GET("/business-hours/elapsed", postNotSupportedHandler)
+			timeGroup.PUT("/business-hours/elapsed", postNotSupportedHandler)
+			timeGroup.DELETE("/business-hours/elapsed", postNotSupportedHandler)
+			timeGroup.PATCH("/business-hours/elapsed", postNotSupportedHandler)
+			timeGroup.OPTIONS("/business-hours/elapsed", postNotSupportedHandler)
+			
+			timeGroup.GET("/lunar/from-solar", postNotSupportedHandler)
+			timeGroup.PUT("/lunar/from-solar", postNotSupportedHandler)
+			timeGroup.DELETE("/lunar/from-solar", postNotSupportedHandler)
+			timeGroup.PATCH("/lunar/from-solar", postNotSupportedHandler)
+			timeGroup.OPTIONS("/lunar/from-solar", postNotSupportedHandler)
+			
+			timeGroup.GET("/lunar/to-solar", postNotSupportedHandler)
+			timeGroup.PUT("/lunar/to-solar", postNotSupportedHandler)
+			timeGroup.DELETE("/lunar/to-solar", postNotSupportedHandler)
+			timeGroup.PATCH("/lunar/to-solar", postNotSupportedHandler)
+			timeGroup.OPTIONS("/lunar/to-solar", postNotSupportedHandler)
+			
+			timeGroup.GET("/diff", postNotSupportedHandler)
+			timeGroup.PUT("/diff", postNotSupportedHandler)
+			timeGroup.DELETE("/diff", postNotSupportedHandler)
+			timeGroup.PATCH("/diff", postNotSupportedHandler)
+			timeGroup.OPTIONS("/diff", postNotSupportedHandler)
+			
+			timeGroup.GET("/add", postNotSupportedHandler)
+			timeGroup.PUT("/add", postNotSupportedHandler)
+			timeGroup.DELETE("/add", postNotSupportedHandler)
+			timeGroup.PATCH("/add", postNotSupportedHandler)
+			timeGroup.OPTIONS("/add", postNotSupportedHandler)
+			
+			timeGroup.GET("/cron", postNotSupportedHandler)
+			timeGroup.PUT("/cron", postNotSupportedHandler)
+			timeGroup.DELETE("/cron", postNotSupportedHandler)
+			timeGroup.PATCH("/cron", postNotSupportedHandler)
+			timeGroup.OPTIONS("/cron", postNotSupportedHandler)
+			
+			timeGroup.GET("/rrule", postNotSupportedHandler)
+			timeGroup.PUT("/rrule", postNotSupportedHandler)
+			timeGroup.DELETE("/rrule", postNotSupportedHandler)
+			timeGroup.PATCH("/rrule", postNotSupportedHandler)
+			timeGroup.OPTIONS("/rrule", postNotSupportedHandler)
+			
+			timeGroup.GET("/parse-natural", postNotSupportedHandler)
+			timeGroup.PUT("/parse-natural", postNotSupportedHandler)
+			timeGroup.DELETE("/parse-natural", postNotSupportedHandler)
+			timeGroup.PATCH("/parse-natural", postNotSupportedHandler)
+			timeGroup.OPTIONS("/parse-natural", postNotSupportedHandler)
+			
+			timeGroup.GET("/calendar-grid", postNotSupportedHandler)
+			timeGroup.PUT("/calendar-grid", postNotSupportedHandler)
+			timeGroup.DELETE("/calendar-grid", postNotSupportedHandler)
+			timeGroup.PATCH("/calendar-grid", postNotSupportedHandler)
+			timeGroup.OPTIONS("/calendar-grid", postNotSupportedHandler)
+			
+			timeGroup.GET("/range", postNotSupportedHandler)
+			timeGroup.PUT("/range", postNotSupportedHandler)
+			timeGroup.DELETE("/range", postNotSupportedHandler)
+			timeGroup.PATCH("/range", postNotSupportedHandler)
+			timeGroup.OPTIONS("/range", postNotSupportedHandler)
+			
+			timeGroup.GET("/shift-schedule", postNotSupportedHandler)
+			timeGroup.PUT("/shift-schedule", postNotSupportedHandler)
+			timeGroup.DELETE("/shift-schedule", postNotSupportedHandler)
+			timeGroup.PATCH("/shift-schedule", postNotSupportedHandler)
+			timeGroup.OPTIONS("/shift-schedule", postNotSupportedHandler)
+			
+			timeGroup.GET("/convert/batch", postNotSupportedHandler)
+			timeGroup.PUT("/convert/batch", postNotSupportedHandler)
+			timeGroup.DELETE("/convert/batch", postNotSupportedHandler)
+			timeGroup.PATCH("/convert/batch", postNotSupportedHandler)
+			timeGroup.OPTIONS("/convert/batch", postNotSupportedHandler)
+			
+			timeGroup.GET("/truncate", postNotSupportedHandler)
+			timeGroup.PUT("/truncate", postNotSupportedHandler)
+			timeGroup.DELETE("/truncate", postNotSupportedHandler)
+			timeGroup.PATCH("/truncate", postNotSupportedHandler)
+			timeGroup.OPTIONS("/truncate", postNotSupportedHandler)
+			
+			timeGroup.GET("/meeting-planner", postNotSupportedHandler)
+			timeGroup.PUT("/meeting-planner", postNotSupportedHandler)
+			timeGroup.DELETE("/meeting-planner", postNotSupportedHandler)
+			timeGroup.PATCH("/meeting-planner", postNotSupportedHandler)
+			timeGroup.OPTIONS("/meeting-planner", postNotSupportedHandler)
+			
 			// 为GET接口添加方法不支持的处理
 			getNotSupportedHandler := func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{
@@ -161,6 +307,67 @@ func SetupRouter() *gin.Engine {
 			timeGroup.DELETE("/timezone-info", getNotSupportedHandler)
 			timeGroup.PATCH("/timezone-info", getNotSupportedHandler)
 			timeGroup.OPTIONS("/timezone-info", getNotSupportedHandler)
+			
+			timeGroup.POST("/holidays", getNotSupportedHandler)
+			timeGroup.PUT("/holidays", getNotSupportedHandler)
+			timeGroup.DELETE("/holidays", getNotSupportedHandler)
+			timeGroup.PATCH("/holidays", getNotSupportedHandler)
+			timeGroup.OPTIONS("/holidays", getNotSupportedHandler)
+			
+			timeGroup.POST("/lunar/solar-terms", getNotSupportedHandler)
+			timeGroup.PUT("/lunar/solar-terms", getNotSupportedHandler)
+			timeGroup.DELETE("/lunar/solar-terms", getNotSupportedHandler)
+			timeGroup.PATCH("/lunar/solar-terms", getNotSupportedHandler)
+			timeGroup.OPTIONS("/lunar/solar-terms", getNotSupportedHandler)
+			
+			timeGroup.POST("/timezones", getNotSupportedHandler)
+			timeGroup.PUT("/timezones", getNotSupportedHandler)
+			timeGroup.DELETE("/timezones", getNotSupportedHandler)
+			timeGroup.PATCH("/timezones", getNotSupportedHandler)
+			timeGroup.OPTIONS("/timezones", getNotSupportedHandler)
+			
+			timeGroup.POST("/timezones/transitions", getNotSupportedHandler)
+			timeGroup.PUT("/timezones/transitions", getNotSupportedHandler)
+			timeGroup.DELETE("/timezones/transitions", getNotSupportedHandler)
+			timeGroup.PATCH("/timezones/transitions", getNotSupportedHandler)
+			timeGroup.OPTIONS("/timezones/transitions", getNotSupportedHandler)
+			
+			timeGroup.POST("/period", getNotSupportedHandler)
+			timeGroup.PUT("/period", getNotSupportedHandler)
+			timeGroup.DELETE("/period", getNotSupportedHandler)
+			timeGroup.PATCH("/period", getNotSupportedHandler)
+			timeGroup.OPTIONS("/period", getNotSupportedHandler)
+			
+			timeGroup.POST("/age", getNotSupportedHandler)
+			timeGroup.PUT("/age", getNotSupportedHandler)
+			timeGroup.DELETE("/age", getNotSupportedHandler)
+			timeGroup.PATCH("/age", getNotSupportedHandler)
+			timeGroup.OPTIONS("/age", getNotSupportedHandler)
+			
+			// 日历管理接口的其他HTTP方法处理
+			calendarNotSupportedHandler := func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{
+					"code":    4005,
+					"message": "请求方法错误: 日历列表支持GET/POST，日历详情支持GET/PUT/DELETE，导入仅支持POST，导出仅支持GET",
+					"data":    nil,
+				})
+			}
+			timeGroup.PUT("/calendars", calendarNotSupportedHandler)
+			timeGroup.DELETE("/calendars", calendarNotSupportedHandler)
+			timeGroup.PATCH("/calendars", calendarNotSupportedHandler)
+			timeGroup.OPTIONS("/calendars", calendarNotSupportedHandler)
+			
+			timeGroup.GET("/calendars/import", calendarNotSupportedHandler)
+			timeGroup.PUT("/calendars/import", calendarNotSupportedHandler)
+			timeGroup.DELETE("/calendars/import", calendarNotSupportedHandler)
+			
+			timeGroup.POST("/calendars/:id", calendarNotSupportedHandler)
+			timeGroup.PATCH("/calendars/:id", calendarNotSupportedHandler)
+			timeGroup.OPTIONS("/calendars/:id", calendarNotSupportedHandler)
+			
+			timeGroup.POST("/calendars/:id/export", calendarNotSupportedHandler)
+			timeGroup.PUT("/calendars/:id/export", calendarNotSupportedHandler)
+			timeGroup.DELETE("/calendars/:id/export", calendarNotSupportedHandler)
 		}
 	}
 	
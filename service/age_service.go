@@ -0,0 +1,167 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"time"
+)
+
+// 星座及起始日期（月*100+日），按日期升序排列
+var CONSTELLATIONS = []struct {
+	Start int
+	Name  string
+}{
+	{120, "水瓶座"}, {219, "双鱼座"}, {321, "白羊座"}, {420, "金牛座"},
+	{521, "双子座"}, {622, "巨蟹座"}, {723, "狮子座"}, {823, "处女座"},
+	{923, "天秤座"}, {1024, "天蝎座"}, {1123, "射手座"}, {1222, "摩羯座"},
+}
+
+// 根据公历月日获取星座及日期范围，如 白羊座、3月21日-4月19日
+func constellationOf(month time.Month, day int) (string, string) {
+	value := int(month)*100 + day
+	index := len(CONSTELLATIONS) - 1 // 1月1日至1月19日为摩羯座
+	for i, c := range CONSTELLATIONS {
+		if value >= c.Start {
+			index = i
+		}
+	}
+
+	start := CONSTELLATIONS[index].Start
+	next := CONSTELLATIONS[(index+1)%len(CONSTELLATIONS)].Start
+	// 结束日期为下一星座起始日的前一天（起始日均不早于每月19日，减1不会跨月）
+	dateRange := fmt.Sprintf("%d月%d日-%d月%d日", start/100, start%100, next/100, next%100-1)
+	return CONSTELLATIONS[index].Name, dateRange
+}
+
+// 农历生日在指定农历年的公历日期，当年该月天数不足时取月末，闰月生日按同名普通月份计算
+func lunarBirthdayIn(year, month, day int) (time.Time, error) {
+	if monthDays := lunarMonthDays(year, month); day > monthDays {
+		day = monthDays
+	}
+	return lunarToSolar(year, month, day, false)
+}
+
+// 计算年龄、下次生日、生肖和星座
+func CalculateAge(req model.AgeRequest) (*model.AgeResponse, error) {
+	calendarType := req.CalendarType
+	if calendarType == "" {
+		calendarType = "solar"
+	}
+	if calendarType != "solar" && calendarType != "lunar" {
+		return nil, &model.ErrorResponse{Code: 3017, Message: "日历类型错误，应为solar或lunar"}
+	}
+
+	// 出生日期格式使用与其他日期接口相同的校验
+	if !utils.IsValidDateFormat(req.BirthDate) {
+		return nil, &model.ErrorResponse{Code: 3017, Message: "出生日期格式错误，应为YYYY-MM-DD"}
+	}
+
+	var birth time.Time
+	var lunarBirth *lunarDate
+	if calendarType == "lunar" {
+		var year, month, day int
+		if _, err := fmt.Sscanf(req.BirthDate, "%d-%d-%d", &year, &month, &day); err != nil {
+			return nil, &model.ErrorResponse{Code: 3017, Message: "出生日期解析失败: " + err.Error()}
+		}
+		solar, err := lunarToSolar(year, month, day, req.IsLeapMonth)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3017, Message: err.Error()}
+		}
+		birth = solar
+	} else {
+		d, err := utils.ParseDate(req.BirthDate)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3017, Message: "出生日期解析失败: " + err.Error()}
+		}
+		birth = d
+	}
+	// 超出农历数据范围时不返回生肖和虚岁
+	if lunar, err := solarToLunar(birth); err == nil {
+		lunarBirth = lunar
+	}
+
+	// 参考日期，默认今天（北京时间）
+	var reference time.Time
+	if req.ReferenceDate == "" {
+		now := time.Now().In(beijingZone)
+		reference = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	} else {
+		if !utils.IsValidDateFormat(req.ReferenceDate) {
+			return nil, &model.ErrorResponse{Code: 3017, Message: "参考日期格式错误，应为YYYY-MM-DD"}
+		}
+		d, err := utils.ParseDate(req.ReferenceDate)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3017, Message: "参考日期解析失败: " + err.Error()}
+		}
+		reference = d
+	}
+	if reference.Before(birth) {
+		return nil, &model.ErrorResponse{Code: 3017, Message: "参考日期不能早于出生日期"}
+	}
+
+	diff := calculateCalendarDiff(birth, reference)
+
+	// 下次生日（当天为生日时即为当天）：公历2月29日生日在平年按2月28日计算
+	var nextBirthday time.Time
+	if calendarType == "lunar" {
+		referenceLunar, err := solarToLunar(reference)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3017, Message: err.Error()}
+		}
+		year := referenceLunar.Year
+		for {
+			if year > LUNAR_MAX_YEAR {
+				return nil, &model.ErrorResponse{Code: 3017, Message: fmt.Sprintf("下次农历生日超出支持范围，最晚支持农历%d年", LUNAR_MAX_YEAR)}
+			}
+			candidate, err := lunarBirthdayIn(year, lunarBirth.Month, lunarBirth.Day)
+			if err != nil {
+				return nil, &model.ErrorResponse{Code: 3017, Message: err.Error()}
+			}
+			if !candidate.Before(reference) && year > lunarBirth.Year {
+				nextBirthday = candidate
+				break
+			}
+			year++
+		}
+	} else {
+		years := reference.Year() - birth.Year()
+		nextBirthday = addMonthsClamped(birth, years*12)
+		if nextBirthday.Before(reference) || years == 0 {
+			nextBirthday = addMonthsClamped(birth, (years+1)*12)
+		}
+	}
+	nextBirthdayAge := nextBirthday.Year() - birth.Year()
+	if calendarType == "lunar" {
+		nextBirthdayLunar, _ := solarToLunar(nextBirthday)
+		nextBirthdayAge = nextBirthdayLunar.Year - lunarBirth.Year
+	}
+
+	constellation, constellationRange := constellationOf(birth.Month(), birth.Day())
+	response := &model.AgeResponse{
+		BirthDate:             birth.Format("2006-01-02"),
+		CalendarType:          calendarType,
+		ReferenceDate:         reference.Format("2006-01-02"),
+		Age:                   diff.Years,
+		AgeDescription:        fmt.Sprintf("%d岁%d个月%d天", diff.Years, diff.Months, diff.Days),
+		DaysLived:             int(reference.Sub(birth).Hours() / 24),
+		NextBirthday:          nextBirthday.Format("2006-01-02"),
+		DaysUntilNextBirthday: int(nextBirthday.Sub(reference).Hours() / 24),
+		NextBirthdayAge:       nextBirthdayAge,
+		IsBirthdayToday:       nextBirthday.Equal(reference),
+		Constellation:         constellation,
+		ConstellationRange:    constellationRange,
+	}
+
+	// 生肖和虚岁以农历正月初一为界
+	if lunarBirth != nil {
+		yearIndex := ganzhiYearIndex(lunarBirth.Year)
+		response.Zodiac = CHINESE_ZODIAC[yearIndex%12]
+		response.LunarBirthDate = fmt.Sprintf("%s年%s%s", ganzhiName(yearIndex), lunarMonthName(lunarBirth.Month, lunarBirth.IsLeap), LUNAR_DAY_NAMES[lunarBirth.Day-1])
+		if referenceLunar, err := solarToLunar(reference); err == nil {
+			response.NominalAge = referenceLunar.Year - lunarBirth.Year + 1
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 默认工作时段
+var DEFAULT_WORKING_HOURS = []string{"09:00-18:00"}
+
+// 工作时段计算的最大跨度（自然日）
+const maxBusinessSpanDays = 3660
+
+// 工作时段（当天零点起的分钟数，左闭右开）
+type workingPeriod struct {
+	start int
+	end   int
+}
+
+// 工作时间日历：工作日判定 + 每日工作时段 + 时区
+type businessCalendar struct {
+	rule    *workdayRule
+	periods []workingPeriod
+	loc     *time.Location
+}
+
+// 解析 HH:MM 格式的时间，允许 24:00 表示当天结束
+func parseClockMinutes(s string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("时间格式错误: %s，应为HH:MM", s)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("时间格式错误: %s，应为HH:MM", s)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("时间格式错误: %s，应为HH:MM", s)
+	}
+	if hour < 0 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("时间超出范围: %s", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// 解析工作时段列表，如 ["09:00-12:00", "13:30-18:00"]
+func parseWorkingHours(workingHours []string) ([]workingPeriod, error) {
+	if len(workingHours) == 0 {
+		workingHours = DEFAULT_WORKING_HOURS
+	}
+
+	periods := make([]workingPeriod, 0, len(workingHours))
+	for _, item := range workingHours {
+		parts := strings.Split(item, "-")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("工作时段格式错误: %s，应为HH:MM-HH:MM", item)
+		}
+		start, err := parseClockMinutes(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClockMinutes(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if end <= start {
+			return nil, fmt.Errorf("工作时段结束时间必须晚于开始时间: %s", item)
+		}
+		periods = append(periods, workingPeriod{start: start, end: end})
+	}
+
+	// 按开始时间排序并检查重叠
+	sort.Slice(periods, func(i, j int) bool { return periods[i].start < periods[j].start })
+	for i := 1; i < len(periods); i++ {
+		if periods[i].start < periods[i-1].end {
+			return nil, fmt.Errorf("工作时段存在重叠: %s", strings.Join(workingHours, ", "))
+		}
+	}
+
+	return periods, nil
+}
+
+// 格式化工作时段
+func formatWorkingPeriods(periods []workingPeriod) []string {
+	result := make([]string, 0, len(periods))
+	for _, p := range periods {
+		result = append(result, fmt.Sprintf("%02d:%02d-%02d:%02d", p.start/60, p.start%60, p.end/60, p.end%60))
+	}
+	return result
+}
+
+// 创建工作时间日历
+func newBusinessCalendar(opts model.BusinessHoursOptions) (*businessCalendar, *model.TimezoneInfo, error) {
+	rule, err := newWorkdayRule(opts.WorkdayOptions)
+	if err != nil {
+		return nil, nil, workdayRuleError(err, 3006)
+	}
+
+	periods, err := parseWorkingHours(opts.WorkingHours)
+	if err != nil {
+		return nil, nil, &model.ErrorResponse{Code: 3006, Message: err.Error()}
+	}
+
+	loc, timezoneInfo, err := resolveLocation(opts.Timezone, opts.TzOffset)
+	if err != nil {
+		return nil, nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	return &businessCalendar{rule: rule, periods: periods, loc: loc}, timezoneInfo, nil
+}
+
+// 获取某天的工作时段，休息日返回空
+func (b *businessCalendar) dayPeriods(day time.Time) [][2]time.Time {
+	if b.rule.classify(day).IsRest {
+		return nil
+	}
+	year, month, date := day.Date()
+	result := make([][2]time.Time, 0, len(b.periods))
+	for _, p := range b.periods {
+		start := time.Date(year, month, date, p.start/60, p.start%60, 0, 0, b.loc)
+		end := time.Date(year, month, date, p.end/60, p.end%60, 0, 0, b.loc)
+		result = append(result, [2]time.Time{start, end})
+	}
+	return result
+}
+
+// 计算从start开始经过duration工作时长后的截止时间，同时返回实际开始计时的时间
+func (b *businessCalendar) addBusinessDuration(start time.Time, duration time.Duration) (time.Time, time.Time, error) {
+	start = start.In(b.loc)
+	remaining := duration
+	var effectiveStart time.Time
+
+	year, month, date := start.Date()
+	day := time.Date(year, month, date, 0, 0, 0, 0, b.loc)
+	for i := 0; i <= maxBusinessSpanDays; i++ {
+		for _, period := range b.dayPeriods(day) {
+			if !start.Before(period[1]) {
+				continue
+			}
+			from := period[0]
+			if start.After(from) {
+				from = start
+			}
+			if effectiveStart.IsZero() {
+				effectiveStart = from
+			}
+			available := period[1].Sub(from)
+			if available >= remaining {
+				return from.Add(remaining), effectiveStart, nil
+			}
+			remaining -= available
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("%d天内无法完成所需工作时长，请检查工作时段和休息日规则", maxBusinessSpanDays)
+}
+
+// 计算两个时间点之间的工作时长，同时返回涉及的工作日数
+func (b *businessCalendar) businessDurationBetween(start, end time.Time) (time.Duration, int) {
+	start = start.In(b.loc)
+	end = end.In(b.loc)
+
+	var total time.Duration
+	workdays := 0
+	year, month, date := start.Date()
+	day := time.Date(year, month, date, 0, 0, 0, 0, b.loc)
+	for !day.After(end) {
+		counted := false
+		for _, period := range b.dayPeriods(day) {
+			from, to := period[0], period[1]
+			if start.After(from) {
+				from = start
+			}
+			if end.Before(to) {
+				to = end
+			}
+			if to.After(from) {
+				total += to.Sub(from)
+				counted = true
+			}
+		}
+		if counted {
+			workdays++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total, workdays
+}
+
+// 格式化时长为中文描述，如 8小时30分钟
+func formatDurationChinese(d time.Duration) string {
+	if d < 0 {
+		return "-" + formatDurationChinese(-d)
+	}
+	totalSeconds := int64(d / time.Second)
+	hours := totalSeconds / 3600
+	minutes := (totalSeconds % 3600) / 60
+	seconds := totalSeconds % 60
+
+	var b strings.Builder
+	if hours > 0 {
+		b.WriteString(fmt.Sprintf("%d小时", hours))
+	}
+	if minutes > 0 {
+		b.WriteString(fmt.Sprintf("%d分钟", minutes))
+	}
+	if seconds > 0 || b.Len() == 0 {
+		b.WriteString(fmt.Sprintf("%d秒", seconds))
+	}
+	return b.String()
+}
+
+// 计算工作时间截止时间（如"8个工作小时内响应"）
+func CalculateBusinessDeadline(req model.BusinessDeadlineRequest) (*model.BusinessDeadlineResponse, error) {
+	cal, timezoneInfo, err := newBusinessCalendar(req.BusinessHoursOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析开始时间，默认当前时间
+	start := time.Now().In(cal.loc)
+	if req.StartTime != nil {
+		start, _, err = parseTimeInput(req.StartTime, cal.loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3006, Message: "开始时间" + err.Error()}
+		}
+	}
+
+	duration := time.Duration(req.Hours*float64(time.Hour)) + time.Duration(req.Minutes)*time.Minute
+	if duration <= 0 {
+		return nil, &model.ErrorResponse{Code: 3006, Message: "工作时长必须大于0，请设置hours或minutes"}
+	}
+
+	deadline, effectiveStart, err := cal.addBusinessDuration(start, duration)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3006, Message: err.Error()}
+	}
+	deadline = deadline.In(cal.loc)
+
+	return &model.BusinessDeadlineResponse{
+		StartTime:         start.In(cal.loc).Format(time.RFC3339),
+		EffectiveStart:    effectiveStart.In(cal.loc).Format(time.RFC3339),
+		Deadline:          deadline.Format(time.RFC3339),
+		DeadlineTimestamp: deadline.Unix(),
+		BusinessSeconds:   int64(duration / time.Second),
+		BusinessDuration:  formatDurationChinese(duration),
+		CalendarDuration:  formatDurationChinese(deadline.Sub(start)),
+		WorkingHours:      formatWorkingPeriods(cal.periods),
+		Timezone:          timezoneInfo.Name,
+		TimezoneInfo:      *timezoneInfo,
+	}, nil
+}
+
+// 计算两个时间点之间经过的工作时长
+func CalculateBusinessElapsed(req model.BusinessElapsedRequest) (*model.BusinessElapsedResponse, error) {
+	cal, timezoneInfo, err := newBusinessCalendar(req.BusinessHoursOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	start, _, err := parseTimeInput(req.StartTime, cal.loc)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3006, Message: "开始时间" + err.Error()}
+	}
+
+	// 结束时间默认当前时间
+	end := time.Now().In(cal.loc)
+	if req.EndTime != nil {
+		end, _, err = parseTimeInput(req.EndTime, cal.loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3006, Message: "结束时间" + err.Error()}
+		}
+	}
+
+	// 结束时间早于开始时间时交换计算，结果为负数
+	sign := time.Duration(1)
+	from, to := start, end
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+	if to.Sub(from) > maxBusinessSpanDays*24*time.Hour {
+		return nil, &model.ErrorResponse{Code: 3006, Message: fmt.Sprintf("时间跨度过大，最大支持%d天", maxBusinessSpanDays)}
+	}
+
+	elapsed, workdays := cal.businessDurationBetween(from, to)
+	elapsed *= sign
+
+	return &model.BusinessElapsedResponse{
+		StartTime:        start.In(cal.loc).Format(time.RFC3339),
+		EndTime:          end.In(cal.loc).Format(time.RFC3339),
+		BusinessSeconds:  int64(elapsed / time.Second),
+		BusinessHours:    math.Round(elapsed.Hours()*100) / 100,
+		BusinessDuration: formatDurationChinese(elapsed),
+		Workdays:         workdays,
+		WorkingHours:     formatWorkingPeriods(cal.periods),
+		Timezone:         timezoneInfo.Name,
+		TimezoneInfo:     *timezoneInfo,
+	}, nil
+}
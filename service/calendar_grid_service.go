@@ -0,0 +1,180 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"time"
+)
+
+// 日历网格中的农历信息，超出农历数据范围时返回nil
+func calendarGridLunar(date time.Time) *model.CalendarGridLunar {
+	lunar, err := solarToLunar(date)
+	if err != nil {
+		return nil
+	}
+
+	solarTerm := solarTermOfDate(date)
+	festivals := lunarFestivals(lunar, solarTerm)
+	result := &model.CalendarGridLunar{
+		MonthName:   lunarMonthName(lunar.Month, lunar.IsLeap),
+		DayName:     LUNAR_DAY_NAMES[lunar.Day-1],
+		IsLeapMonth: lunar.IsLeap,
+		SolarTerm:   solarTerm,
+		Festivals:   festivals,
+	}
+
+	// 格子中优先显示节日，其次节气，初一显示月份
+	switch {
+	case len(festivals) > 0:
+		result.DisplayText = festivals[0]
+	case solarTerm != "":
+		result.DisplayText = solarTerm
+	case lunar.Day == 1:
+		result.DisplayText = result.MonthName
+	default:
+		result.DisplayText = result.DayName
+	}
+	return result
+}
+
+// 生成单月日历网格，首尾用前后月份的日期补足整周
+func buildCalendarGridMonth(year int, month time.Month, startWithMonday bool, includeLunar bool, rule *workdayRule) model.CalendarGridMonth {
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	// 网格第一天为首周的周起始日
+	offset := int(firstDay.Weekday())
+	if startWithMonday {
+		offset = (offset + 6) % 7
+	}
+	current := firstDay.AddDate(0, 0, -offset)
+
+	result := model.CalendarGridMonth{
+		Year:  year,
+		Month: int(month),
+		Days:  lastDay.Day(),
+		Weeks: []model.CalendarGridWeek{},
+	}
+
+	for !current.After(lastDay) {
+		// 周数以本周内属于当月的第一天计算，ISO周数以本周的周一计算
+		anchor := current
+		if anchor.Before(firstDay) {
+			anchor = firstDay
+		}
+		monday := current
+		if !startWithMonday {
+			monday = current.AddDate(0, 0, 1)
+		}
+		isoYear, isoWeek := monday.ISOWeek()
+
+		week := model.CalendarGridWeek{
+			WeekInMonth: utils.GetWeekNumberInMonth(anchor, startWithMonday),
+			WeekInYear:  utils.GetWeekNumberInYear(anchor, startWithMonday),
+			ISOYear:     isoYear,
+			ISOWeek:     isoWeek,
+			Days:        make([]model.CalendarGridDay, 0, 7),
+		}
+
+		for i := 0; i < 7; i++ {
+			day := rule.classify(current)
+			inMonth := current.Month() == month
+			gridDay := model.CalendarGridDay{
+				Date:        current.Format("2006-01-02"),
+				Day:         current.Day(),
+				Weekday:     int(current.Weekday()),
+				WeekdayName: utils.WeekdayNames[current.Weekday()],
+				InMonth:     inMonth,
+				IsRest:      day.IsRest,
+				RestType:    day.RestType,
+				Reason:      day.Reason,
+			}
+			if includeLunar {
+				gridDay.Lunar = calendarGridLunar(current)
+			}
+			week.Days = append(week.Days, gridDay)
+
+			// 只统计当月的工作日和休息日
+			if inMonth {
+				if day.IsRest {
+					result.Restdays++
+				} else {
+					result.Workdays++
+				}
+			}
+			current = current.AddDate(0, 0, 1)
+		}
+		result.Weeks = append(result.Weeks, week)
+	}
+
+	return result
+}
+
+// 生成月或全年的日历网格
+func GetCalendarGrid(req model.CalendarGridRequest) (*model.CalendarGridResponse, error) {
+	year := req.Year
+	if year == 0 {
+		year = time.Now().In(beijingZone).Year()
+	}
+	if year < 1 || year > 9999 {
+		return nil, &model.ErrorResponse{Code: 3013, Message: "年份超出支持范围(1-9999)"}
+	}
+	if req.Month < 0 || req.Month > 12 {
+		return nil, &model.ErrorResponse{Code: 3013, Message: "月份必须在1-12之间，为0时返回全年"}
+	}
+	if req.IncludeLunar && (year < LUNAR_MIN_YEAR || year > LUNAR_MAX_YEAR) {
+		return nil, &model.ErrorResponse{Code: 3013, Message: fmt.Sprintf("包含农历时年份超出支持范围(%d-%d)", LUNAR_MIN_YEAR, LUNAR_MAX_YEAR)}
+	}
+
+	startWithMonday := true
+	if req.StartWithMonday != nil {
+		startWithMonday = *req.StartWithMonday
+	}
+
+	rule, err := newWorkdayRule(req.WorkdayOptions)
+	if err != nil {
+		return nil, workdayRuleError(err, 3013)
+	}
+
+	// 按周起始日排列表头
+	startDay := "周日"
+	first := 0
+	if startWithMonday {
+		startDay = "周一"
+		first = 1
+	}
+	headers := make([]string, 0, 7)
+	for i := 0; i < 7; i++ {
+		headers = append(headers, utils.WeekdayNames[(first+i)%7])
+	}
+
+	firstMonth, lastMonth := 1, 12
+	if req.Month != 0 {
+		firstMonth, lastMonth = req.Month, req.Month
+	}
+	months := make([]model.CalendarGridMonth, 0, lastMonth-firstMonth+1)
+	for month := firstMonth; month <= lastMonth; month++ {
+		months = append(months, buildCalendarGridMonth(year, time.Month(month), startWithMonday, req.IncludeLunar, rule))
+	}
+
+	response := &model.CalendarGridResponse{
+		Year:           year,
+		Month:          req.Month,
+		StartDay:       startDay,
+		WeekdayHeaders: headers,
+		Months:         months,
+		Calendar:       rule.calendar,
+		CalendarID:     req.CalendarID,
+	}
+
+	if rule.calendar == CALENDAR_CN {
+		response.CalendarVersion = CN_HOLIDAY_DATA_VERSION
+		response.CalendarMissingYears = rule.missingYears(
+			time.Date(year, time.Month(firstMonth), 1, 0, 0, 0, 0, time.UTC),
+			time.Date(year, time.Month(lastMonth)+1, 0, 0, 0, 0, 0, time.UTC),
+		)
+	}
+
+	return response, nil
+}
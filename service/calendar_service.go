@@ -0,0 +1,572 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 自定义日历存储文件
+const calendarStorePath = "toolbox_data/toolbox_calendars.json"
+
+const (
+	maxCalendarDates = 10000 // 每个日历的休息日与上班日总数上限
+	maxICSEventDays  = 366   // iCalendar单个事件最多展开的天数
+	calendarMinYear  = 1900  // 日历日期支持的年份范围，与工作日计算的数据范围一致
+	calendarMaxYear  = 2100
+)
+
+// 日历ID格式
+var calendarIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// 自定义日历存储
+var (
+	calendars       map[string]*model.Calendar
+	calendarsLoaded bool
+	calendarsMu     sync.RWMutex
+)
+
+// 从文件加载日历（调用方需持有写锁）
+func loadCalendarsLocked() error {
+	if calendarsLoaded {
+		return nil
+	}
+
+	calendars = make(map[string]*model.Calendar)
+	data, err := os.ReadFile(calendarStorePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			calendarsLoaded = true
+			return nil
+		}
+		return err
+	}
+
+	var list []*model.Calendar
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, cal := range list {
+		calendars[cal.ID] = cal
+	}
+	calendarsLoaded = true
+	return nil
+}
+
+// 确保日历已加载
+func ensureCalendarsLoaded() error {
+	calendarsMu.RLock()
+	loaded := calendarsLoaded
+	calendarsMu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	return loadCalendarsLocked()
+}
+
+// 将日历写入文件（调用方需持有写锁）
+func saveCalendarsLocked() error {
+	list := make([]*model.Calendar, 0, len(calendars))
+	for _, cal := range calendars {
+		list = append(list, cal)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(calendarStorePath), 0755); err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，避免写入中断导致文件损坏
+	tmpPath := calendarStorePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, calendarStorePath)
+}
+
+// 生成日历ID
+func generateCalendarID() string {
+	randomBytes := make([]byte, 6)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return fmt.Sprintf("cal-%d", time.Now().UnixNano())
+	}
+	return "cal-" + hex.EncodeToString(randomBytes)
+}
+
+// 校验并规范化日历内容
+func normalizeCalendar(cal *model.Calendar) error {
+	if cal.ID == CALENDAR_CN {
+		return fmt.Errorf("日历ID不能使用内置日历名称: %s", CALENDAR_CN)
+	}
+	if !calendarIDPattern.MatchString(cal.ID) {
+		return fmt.Errorf("日历ID格式错误，仅支持1-64位字母、数字、下划线和连字符")
+	}
+	if strings.TrimSpace(cal.Name) == "" {
+		return fmt.Errorf("日历名称不能为空")
+	}
+	if cal.BaseCalendar != "" && cal.BaseCalendar != CALENDAR_CN {
+		return fmt.Errorf("不支持的节假日日历: %s，可选值: %s", cal.BaseCalendar, CALENDAR_CN)
+	}
+	if cal.RestDayPattern != "" && !isValidRestDayPattern(cal.RestDayPattern) {
+		return fmt.Errorf("休息日模式格式错误，应为7位0/1字符串（周一到周日，1表示休息）")
+	}
+
+	if len(cal.Holidays)+len(cal.Workdays) > maxCalendarDates {
+		return fmt.Errorf("休息日和上班日总数不能超过%d", maxCalendarDates)
+	}
+
+	// 去重并排序，同一日期不能既是休息日又是上班日
+	holidays, err := normalizeCalendarDates(cal.Holidays)
+	if err != nil {
+		return err
+	}
+	workdays, err := normalizeCalendarDates(cal.Workdays)
+	if err != nil {
+		return err
+	}
+	holidaySet := make(map[string]bool, len(holidays))
+	for _, day := range holidays {
+		holidaySet[day.Date] = true
+	}
+	for _, day := range workdays {
+		if holidaySet[day.Date] {
+			return fmt.Errorf("日期%s不能同时为休息日和上班日", day.Date)
+		}
+	}
+
+	cal.Holidays = holidays
+	cal.Workdays = workdays
+	return nil
+}
+
+// 校验日期列表，去重并按日期排序
+func normalizeCalendarDates(dates []model.CalendarDate) ([]model.CalendarDate, error) {
+	seen := make(map[string]int)
+	result := []model.CalendarDate{}
+	for _, day := range dates {
+		if !utils.IsValidDateFormat(day.Date) {
+			return nil, fmt.Errorf("日期格式错误: %s，应为YYYY-MM-DD", day.Date)
+		}
+		date, err := utils.ParseDate(day.Date)
+		if err != nil {
+			return nil, fmt.Errorf("日期解析失败: %s", day.Date)
+		}
+		if date.Year() < calendarMinYear || date.Year() > calendarMaxYear {
+			return nil, fmt.Errorf("日期%s超出支持范围(%d-%d)", day.Date, calendarMinYear, calendarMaxYear)
+		}
+		if idx, ok := seen[day.Date]; ok {
+			// 重复日期保留最后一个名称
+			result[idx].Name = day.Name
+			continue
+		}
+		seen[day.Date] = len(result)
+		result = append(result, day)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Date < result[j].Date })
+	return result, nil
+}
+
+// 获取日历（供工作日判定使用）
+func getCalendar(id string) (*model.Calendar, error) {
+	if err := ensureCalendarsLoaded(); err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: "加载日历数据失败: " + err.Error()}
+	}
+
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	cal, ok := calendars[id]
+	if !ok {
+		return nil, &model.ErrorResponse{Code: 3004, Message: "日历不存在: " + id}
+	}
+	copied := *cal
+	return &copied, nil
+}
+
+// 日历保存方式
+type calendarStoreMode int
+
+const (
+	calendarCreate  calendarStoreMode = iota // 仅新建，ID已存在时报错
+	calendarReplace                          // 仅替换，ID不存在时报错
+	calendarUpsert                           // 不存在时新建，存在时替换
+)
+
+// 保存日历，是否存在的检查与写入在同一把锁内完成
+func storeCalendar(cal *model.Calendar, mode calendarStoreMode) (*model.Calendar, error) {
+	if err := normalizeCalendar(cal); err != nil {
+		return nil, &model.ErrorResponse{Code: 3004, Message: err.Error()}
+	}
+
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	if err := loadCalendarsLocked(); err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: "加载日历数据失败: " + err.Error()}
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	if existing, ok := calendars[cal.ID]; ok {
+		if mode == calendarCreate {
+			return nil, &model.ErrorResponse{Code: 3004, Message: "日历ID已存在: " + cal.ID}
+		}
+		cal.CreatedAt = existing.CreatedAt
+	} else {
+		if mode == calendarReplace {
+			return nil, &model.ErrorResponse{Code: 3004, Message: "日历不存在: " + cal.ID}
+		}
+		cal.CreatedAt = now
+	}
+	cal.UpdatedAt = now
+
+	previous, existed := calendars[cal.ID]
+	calendars[cal.ID] = cal
+	if err := saveCalendarsLocked(); err != nil {
+		// 保存失败时回滚内存数据
+		if existed {
+			calendars[cal.ID] = previous
+		} else {
+			delete(calendars, cal.ID)
+		}
+		return nil, &model.ErrorResponse{Code: 9000, Message: "保存日历数据失败: " + err.Error()}
+	}
+
+	copied := *cal
+	return &copied, nil
+}
+
+// 获取日历列表
+func ListCalendars() (*model.CalendarListResponse, error) {
+	if err := ensureCalendarsLoaded(); err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: "加载日历数据失败: " + err.Error()}
+	}
+
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	list := make([]model.Calendar, 0, len(calendars))
+	for _, cal := range calendars {
+		list = append(list, *cal)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+
+	return &model.CalendarListResponse{
+		Calendars: list,
+		Count:     len(list),
+	}, nil
+}
+
+// 获取日历详情
+func GetCalendar(id string) (*model.Calendar, error) {
+	return getCalendar(id)
+}
+
+// 创建日历
+func CreateCalendar(req model.CalendarRequest) (*model.Calendar, error) {
+	if req.ID == "" {
+		req.ID = generateCalendarID()
+	}
+	return storeCalendar(calendarFromRequest(req), calendarCreate)
+}
+
+// 更新日历
+func UpdateCalendar(id string, req model.CalendarRequest) (*model.Calendar, error) {
+	req.ID = id
+	return storeCalendar(calendarFromRequest(req), calendarReplace)
+}
+
+// 删除日历
+func DeleteCalendar(id string) error {
+	if err := ensureCalendarsLoaded(); err != nil {
+		return &model.ErrorResponse{Code: 9000, Message: "加载日历数据失败: " + err.Error()}
+	}
+
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	cal, ok := calendars[id]
+	if !ok {
+		return &model.ErrorResponse{Code: 3004, Message: "日历不存在: " + id}
+	}
+
+	delete(calendars, id)
+	if err := saveCalendarsLocked(); err != nil {
+		calendars[id] = cal
+		return &model.ErrorResponse{Code: 9000, Message: "保存日历数据失败: " + err.Error()}
+	}
+	return nil
+}
+
+// 请求转换为日历
+func calendarFromRequest(req model.CalendarRequest) *model.Calendar {
+	return &model.Calendar{
+		ID:             req.ID,
+		Name:           req.Name,
+		Description:    req.Description,
+		BaseCalendar:   req.BaseCalendar,
+		RestDayPattern: req.RestDayPattern,
+		Holidays:       req.Holidays,
+		Workdays:       req.Workdays,
+	}
+}
+
+// 导入日历（JSON或iCalendar格式）
+func ImportCalendar(req model.CalendarImportRequest) (*model.Calendar, error) {
+	var cal *model.Calendar
+	var err error
+
+	switch strings.ToLower(req.Format) {
+	case "", "json":
+		cal = &model.Calendar{}
+		if err = json.Unmarshal([]byte(req.Content), cal); err != nil {
+			return nil, &model.ErrorResponse{Code: 3004, Message: "JSON日历解析失败: " + err.Error()}
+		}
+	case "ics", "ical", "icalendar":
+		cal, err = parseICalendar(req.Content)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3004, Message: "iCalendar解析失败: " + err.Error()}
+		}
+	default:
+		return nil, &model.ErrorResponse{Code: 3004, Message: "不支持的导入格式: " + req.Format + "，可选值: json, ics"}
+	}
+
+	// 请求参数优先于导入内容
+	if req.ID != "" {
+		cal.ID = req.ID
+	}
+	if req.Name != "" {
+		cal.Name = req.Name
+	}
+	if req.BaseCalendar != "" {
+		cal.BaseCalendar = req.BaseCalendar
+	}
+	if req.RestDayPattern != "" {
+		cal.RestDayPattern = req.RestDayPattern
+	}
+	if cal.ID == "" {
+		cal.ID = generateCalendarID()
+	}
+	if cal.Name == "" {
+		cal.Name = cal.ID
+	}
+
+	return storeCalendar(cal, calendarUpsert)
+}
+
+// 导出日历，返回文件内容、Content-Type和文件名
+func ExportCalendar(id, format string) ([]byte, string, string, error) {
+	cal, err := getCalendar(id)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	switch strings.ToLower(format) {
+	case "", "json":
+		data, err := json.MarshalIndent(cal, "", "  ")
+		if err != nil {
+			return nil, "", "", &model.ErrorResponse{Code: 9000, Message: "导出日历失败: " + err.Error()}
+		}
+		return data, "application/json; charset=utf-8", cal.ID + ".json", nil
+	case "ics", "ical", "icalendar":
+		return []byte(buildICalendar(cal)), "text/calendar; charset=utf-8", cal.ID + ".ics", nil
+	default:
+		return nil, "", "", &model.ErrorResponse{Code: 3004, Message: "不支持的导出格式: " + format + "，可选值: json, ics"}
+	}
+}
+
+// iCalendar文本转义
+func icsEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(s)
+}
+
+// iCalendar文本反转义
+func icsUnescape(s string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return replacer.Replace(s)
+}
+
+// 生成iCalendar内容，每个日期一个全天事件
+func buildICalendar(cal *model.Calendar) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(s)
+		b.WriteString("\r\n")
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//toolbox-api//calendar//CN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:" + icsEscape(cal.Name))
+	if cal.Description != "" {
+		line("X-WR-CALDESC:" + icsEscape(cal.Description))
+	}
+	if cal.BaseCalendar != "" {
+		line("X-TOOLBOX-BASE-CALENDAR:" + cal.BaseCalendar)
+	}
+	if cal.RestDayPattern != "" {
+		line("X-TOOLBOX-REST-DAY-PATTERN:" + cal.RestDayPattern)
+	}
+
+	writeEvent := func(day model.CalendarDate, category string) {
+		date, _ := utils.ParseDate(day.Date)
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s-%s-%s@toolbox-api", cal.ID, date.Format("20060102"), strings.ToLower(category)))
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + date.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + icsEscape(day.Name))
+		line("CATEGORIES:" + category)
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	for _, day := range cal.Holidays {
+		writeEvent(day, "HOLIDAY")
+	}
+	for _, day := range cal.Workdays {
+		writeEvent(day, "WORKDAY")
+	}
+
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// 解析iCalendar日期值（DATE或DATE-TIME，仅取日期部分）
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("日期格式错误: %s", value)
+	}
+	t, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.Year() < calendarMinYear || t.Year() > calendarMaxYear {
+		return time.Time{}, fmt.Errorf("日期%s超出支持范围(%d-%d)", value[:8], calendarMinYear, calendarMaxYear)
+	}
+	return t, nil
+}
+
+// 解析iCalendar内容
+// 全天事件展开为逐日日期；CATEGORIES为WORKDAY或标题含"上班"、"补班"的事件视为上班日，其余视为休息日
+func parseICalendar(content string) (*model.Calendar, error) {
+	// 展开折叠行（以空格或制表符开头的行是上一行的延续）
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	var lines []string
+	for _, raw := range strings.Split(content, "\n") {
+		if (strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += raw[1:]
+			continue
+		}
+		lines = append(lines, raw)
+	}
+
+	cal := &model.Calendar{}
+	inEvent := false
+	eventCount := 0
+	var summary, categories string
+	var start, end time.Time
+	var hasStart, hasEnd bool
+
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		idx := strings.Index(l, ":")
+		if idx < 0 {
+			continue
+		}
+		// 属性名可能带参数，如 DTSTART;VALUE=DATE
+		name := strings.ToUpper(strings.SplitN(l[:idx], ";", 2)[0])
+		value := l[idx+1:]
+
+		if !inEvent {
+			switch name {
+			case "BEGIN":
+				if strings.EqualFold(value, "VEVENT") {
+					inEvent = true
+					summary, categories = "", ""
+					hasStart, hasEnd = false, false
+				}
+			case "X-WR-CALNAME":
+				cal.Name = icsUnescape(value)
+			case "X-WR-CALDESC":
+				cal.Description = icsUnescape(value)
+			case "X-TOOLBOX-BASE-CALENDAR":
+				cal.BaseCalendar = value
+			case "X-TOOLBOX-REST-DAY-PATTERN":
+				cal.RestDayPattern = value
+			}
+			continue
+		}
+
+		switch name {
+		case "SUMMARY":
+			summary = icsUnescape(value)
+		case "CATEGORIES":
+			categories = strings.ToUpper(value)
+		case "DTSTART":
+			t, err := parseICSDate(value)
+			if err != nil {
+				return nil, err
+			}
+			start, hasStart = t, true
+		case "DTEND":
+			t, err := parseICSDate(value)
+			if err != nil {
+				return nil, err
+			}
+			end, hasEnd = t, true
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if !hasStart {
+				return nil, fmt.Errorf("第%d个事件缺少DTSTART", eventCount+1)
+			}
+			eventCount++
+
+			// DTEND不包含在内；缺省或不晚于开始日期时视为单日事件
+			if !hasEnd || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			if end.Sub(start) > maxICSEventDays*24*time.Hour {
+				return nil, fmt.Errorf("第%d个事件跨度超过%d天", eventCount, maxICSEventDays)
+			}
+			if len(cal.Holidays)+len(cal.Workdays)+int(end.Sub(start)/(24*time.Hour)) > maxCalendarDates {
+				return nil, fmt.Errorf("休息日和上班日总数不能超过%d", maxCalendarDates)
+			}
+			isWorkday := strings.Contains(categories, "WORKDAY") ||
+				strings.Contains(summary, "上班") || strings.Contains(summary, "补班")
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				day := model.CalendarDate{Date: d.Format("2006-01-02"), Name: summary}
+				if isWorkday {
+					cal.Workdays = append(cal.Workdays, day)
+				} else {
+					cal.Holidays = append(cal.Holidays, day)
+				}
+			}
+		}
+	}
+
+	if eventCount == 0 {
+		return nil, fmt.Errorf("未找到任何VEVENT事件")
+	}
+	return cal, nil
+}
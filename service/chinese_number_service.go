@@ -0,0 +1,369 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 支持转换的最大整数（不超过仟万亿位）
+const maxChineseNumber = 9999999999999999
+
+// 金额数字格式，如 12345.67、-0.5、1,234.00
+var amountPattern = regexp.MustCompile(`^([+-])?(\d+)(?:\.(\d*))?$`)
+
+// 大写金额的角分部分，如 陆角柒分、零伍分
+var amountFractionPattern = regexp.MustCompile(`^零?(?:(.)角)?零?(?:(.)分)?$`)
+
+// 中文数字单位对应的数值
+var chineseUnitValues = map[rune]int64{
+	'十': 10, '拾': 10, '百': 100, '佰': 100, '千': 1000, '仟': 1000,
+	'万': 10000, '萬': 10000, '亿': 100000000, '億': 100000000,
+}
+
+// 四位以内的数字转换为中文，如 1010 为 壹仟零壹拾
+func chineseSection(section int64, digits, units []string) string {
+	var builder strings.Builder
+	zero := false
+	for pos := 3; pos >= 0; pos-- {
+		d := section / int64(math.Pow10(pos)) % 10
+		if d == 0 {
+			if builder.Len() > 0 {
+				zero = true
+			}
+			continue
+		}
+		if zero {
+			builder.WriteString(digits[0])
+			zero = false
+		}
+		builder.WriteString(digits[d])
+		builder.WriteString(units[pos])
+	}
+	return builder.String()
+}
+
+// 非负整数转换为中文数字，每四位一节，节间按需补"零"，如 10005 为 一万零五
+func chineseInteger(n int64, upper bool) string {
+	digits, units := utils.ChineseNumbersSimple, utils.ChineseUnitsSimple
+	if upper {
+		digits, units = utils.ChineseNumbersUpper, utils.ChineseUnitsUpper
+	}
+	if n == 0 {
+		return digits[0]
+	}
+
+	// 节单位：个、万、亿、万亿
+	sectionUnits := []string{"", units[4], units[8], units[4] + units[8]}
+	sections := []int64{}
+	for n > 0 {
+		sections = append(sections, n%10000)
+		n /= 10000
+	}
+
+	var builder strings.Builder
+	needZero := false
+	for i := len(sections) - 1; i >= 0; i-- {
+		section := sections[i]
+		if section == 0 {
+			if builder.Len() > 0 {
+				needZero = true
+			}
+			continue
+		}
+		if builder.Len() > 0 && (needZero || section < 1000) {
+			builder.WriteString(digits[0])
+		}
+		builder.WriteString(chineseSection(section, digits, units))
+		builder.WriteString(sectionUnits[i])
+		needZero = false
+	}
+	return builder.String()
+}
+
+// 解析中文整数，支持大写、普通及逐位写法（如 一万二千三百四十五、壹拾贰、二〇二四）
+func parseChineseInteger(s string) (int64, error) {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return 0, fmt.Errorf("数字不能为空")
+	}
+
+	// 不含单位时按逐位数字处理
+	hasUnit := false
+	for _, r := range runes {
+		if _, ok := chineseUnitValues[r]; ok {
+			hasUnit = true
+			break
+		}
+	}
+	if !hasUnit {
+		var n int64
+		for _, r := range runes {
+			d, ok := chineseDigitValue(r)
+			if !ok {
+				return 0, fmt.Errorf("无法识别的字符: %s", string(r))
+			}
+			n = n*10 + int64(d)
+			if n > maxChineseNumber {
+				return 0, fmt.Errorf("数字超出支持范围")
+			}
+		}
+		return n, nil
+	}
+
+	var result, section, number, lastUnit int64
+	hasNumber := false
+	for i, r := range runes {
+		if d, ok := chineseDigitValue(r); ok {
+			if d != 0 && i > 0 {
+				if _, ok := chineseUnitValues[runes[i-1]]; !ok {
+					lastUnit = 0
+				}
+			} else {
+				lastUnit = 0
+			}
+			if hasNumber && number != 0 && d != 0 {
+				return 0, fmt.Errorf("第%d个字符处数字缺少单位: %s", i+1, s)
+			}
+			number, hasNumber = int64(d), true
+			continue
+		}
+		unit, ok := chineseUnitValues[r]
+		if !ok {
+			return 0, fmt.Errorf("无法识别的字符: %s", string(r))
+		}
+		switch {
+		case unit < 10000:
+			// 十二、拾贰 等省略"一"的写法
+			if number == 0 && unit == 10 {
+				number = 1
+			}
+			section += number * unit
+		case unit == 10000:
+			section = (section + number) * unit
+		default:
+			// 亿前的部分可含万，如 一万亿、九千万亿
+			result += (section + number) * unit
+			section = 0
+		}
+		number, hasNumber, lastUnit = 0, false, unit
+		if result+section > maxChineseNumber {
+			return 0, fmt.Errorf("数字超出支持范围")
+		}
+	}
+	// 口语中末位数字紧跟单位时表示下一位，如 三百五 为 350、两万三 为 23000
+	if number != 0 && lastUnit >= 100 {
+		number *= lastUnit / 10
+	}
+	return result + section + number, nil
+}
+
+// 解析金额输入，返回金额文本、符号、整数部分和四舍五入到分的角分
+func parseAmountInput(input interface{}) (string, bool, int64, int64, error) {
+	var text string
+	switch v := input.(type) {
+	case string:
+		text = strings.ReplaceAll(strings.TrimSpace(v), ",", "")
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return "", false, 0, 0, fmt.Errorf("金额必须为数字或数字字符串")
+	}
+
+	matches := amountPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return text, false, 0, 0, fmt.Errorf("金额格式错误: %s", text)
+	}
+	if len(strings.TrimLeft(matches[2], "0")) > 16 {
+		return text, false, 0, 0, fmt.Errorf("金额超出支持范围（最大仟万亿位）")
+	}
+	integer, _ := strconv.ParseInt(matches[2], 10, 64)
+
+	// 小数部分按分四舍五入
+	fraction := matches[3] + "000"
+	cents, _ := strconv.ParseInt(fraction[:2], 10, 64)
+	if fraction[2] >= '5' {
+		cents++
+	}
+	if cents == 100 {
+		integer, cents = integer+1, 0
+	}
+	if integer > maxChineseNumber {
+		return text, false, 0, 0, fmt.Errorf("金额超出支持范围（最大仟万亿位）")
+	}
+	return text, matches[1] == "-" && (integer > 0 || cents > 0), integer, cents, nil
+}
+
+// 金额转换为人民币大写，如 12345.67 为 壹万贰仟叁佰肆拾伍元陆角柒分
+func chineseAmount(negative bool, integer, cents int64) string {
+	digits := utils.ChineseNumbersUpper
+	jiao, fen := cents/10, cents%10
+
+	var builder strings.Builder
+	if negative {
+		builder.WriteString("负")
+	}
+	if integer > 0 || cents == 0 {
+		builder.WriteString(chineseInteger(integer, true))
+		builder.WriteString("元")
+	}
+	if jiao > 0 {
+		builder.WriteString(digits[jiao])
+		builder.WriteString("角")
+	} else if fen > 0 && integer > 0 {
+		builder.WriteString(digits[0])
+	}
+	if fen > 0 {
+		builder.WriteString(digits[fen])
+		builder.WriteString("分")
+	}
+
+	// 到元或角为止的金额以"整"结尾
+	if fen == 0 {
+		builder.WriteString("整")
+	}
+	return builder.String()
+}
+
+// 金额转换为人民币大写
+func ConvertAmountToChinese(req model.AmountToChineseRequest) (*model.AmountToChineseResponse, error) {
+	text, negative, integer, cents, err := parseAmountInput(req.Amount)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 4001, Message: err.Error()}
+	}
+
+	amount := fmt.Sprintf("%d.%02d", integer, cents)
+	if negative {
+		amount = "-" + amount
+	}
+
+	return &model.AmountToChineseResponse{
+		Original:  text,
+		Amount:    amount,
+		Converted: chineseAmount(negative, integer, cents),
+	}, nil
+}
+
+// 人民币大写金额解析为数字，如 壹万贰仟叁佰肆拾伍元陆角柒分 为 12345.67
+func ParseChineseAmount(req model.ChineseNumberParseRequest) (*model.ChineseToAmountResponse, error) {
+	text := strings.Join(strings.Fields(req.Text), "")
+	text = strings.TrimPrefix(text, "人民币")
+	negative := strings.HasPrefix(text, "负")
+	text = strings.TrimPrefix(text, "负")
+	text = strings.TrimSuffix(strings.TrimSuffix(text, "整"), "正")
+	text = strings.ReplaceAll(text, "圆", "元")
+	if text == "" {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "金额不能为空"}
+	}
+
+	// 拆分元与角分
+	integerPart, fractionPart := "", text
+	if index := strings.Index(text, "元"); index >= 0 {
+		integerPart, fractionPart = text[:index], text[index+len("元"):]
+	}
+
+	var integer int64
+	if integerPart != "" {
+		n, err := parseChineseInteger(integerPart)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 4001, Message: "金额整数部分格式错误: " + err.Error()}
+		}
+		integer = n
+	}
+
+	var cents int64
+	if fractionPart != "" {
+		matches := amountFractionPattern.FindStringSubmatch(fractionPart)
+		if matches == nil || (matches[1] == "" && matches[2] == "") {
+			return nil, &model.ErrorResponse{Code: 4001, Message: "金额角分部分格式错误: " + fractionPart}
+		}
+		for i, part := range matches[1:] {
+			if part == "" {
+				continue
+			}
+			d, ok := chineseDigitValue([]rune(part)[0])
+			if !ok {
+				return nil, &model.ErrorResponse{Code: 4001, Message: "无法识别的字符: " + part}
+			}
+			if i == 0 {
+				cents += int64(d) * 10
+			} else {
+				cents += int64(d)
+			}
+		}
+	} else if integerPart == "" {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "金额格式错误: " + req.Text}
+	}
+
+	amount := fmt.Sprintf("%d.%02d", integer, cents)
+	value := float64(integer) + float64(cents)/100
+	if negative && (integer > 0 || cents > 0) {
+		amount = "-" + amount
+		value = -value
+	}
+
+	return &model.ChineseToAmountResponse{
+		Original: req.Text,
+		Amount:   amount,
+		Value:    value,
+	}, nil
+}
+
+// 整数转换为中文数字，style为simple（默认，一万二千）或upper（壹万贰仟）
+func ConvertNumberToChinese(req model.NumberToChineseRequest) (*model.NumberToChineseResponse, error) {
+	if req.Style == "" {
+		req.Style = "simple"
+	}
+	if req.Style != "simple" && req.Style != "upper" {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "style必须为simple或upper"}
+	}
+
+	n := *req.Number
+	// 先检查范围再取反，避免math.MinInt64取反溢出
+	if n < -maxChineseNumber || n > maxChineseNumber {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "数字超出支持范围（最大仟万亿位）"}
+	}
+	negative := n < 0
+	if negative {
+		n = -n
+	}
+
+	converted := chineseInteger(n, req.Style == "upper")
+	// 普通写法中10-19开头省略"一"，如 十二、十万
+	if req.Style == "simple" && strings.HasPrefix(converted, "一十") {
+		converted = strings.TrimPrefix(converted, "一")
+	}
+	if negative {
+		converted = "负" + converted
+	}
+
+	return &model.NumberToChineseResponse{
+		Number:    *req.Number,
+		Style:     req.Style,
+		Converted: converted,
+	}, nil
+}
+
+// 中文数字解析为整数
+func ParseChineseNumber(req model.ChineseNumberParseRequest) (*model.ChineseToNumberResponse, error) {
+	text := strings.Join(strings.Fields(req.Text), "")
+	negative := strings.HasPrefix(text, "负")
+	text = strings.TrimPrefix(text, "负")
+
+	n, err := parseChineseInteger(text)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "中文数字格式错误: " + err.Error()}
+	}
+	if negative {
+		n = -n
+	}
+
+	return &model.ChineseToNumberResponse{
+		Original: req.Text,
+		Number:   n,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"github.com/renoz/toolbox-api/model"
+	"math"
+	"testing"
+)
+
+func TestConvertAmountToChinese(t *testing.T) {
+	cases := []struct {
+		amount interface{}
+		want   string
+	}{
+		{"0", "零元整"},
+		{"0.05", "伍分"},
+		{"0.5", "伍角整"},
+		{"10.50", "壹拾元伍角整"},
+		{"10.05", "壹拾元零伍分"},
+		{"12345.67", "壹万贰仟叁佰肆拾伍元陆角柒分"},
+		{"100010000", "壹亿零壹万元整"},
+		{"-1,000.5", "负壹仟元伍角整"},
+		{-0.01, "负壹分"},
+	}
+	for _, c := range cases {
+		got, err := ConvertAmountToChinese(model.AmountToChineseRequest{Amount: c.amount})
+		if err != nil {
+			t.Errorf("ConvertAmountToChinese(%v) error: %v", c.amount, err)
+			continue
+		}
+		if got.Converted != c.want {
+			t.Errorf("ConvertAmountToChinese(%v) = %s, want %s", c.amount, got.Converted, c.want)
+		}
+	}
+}
+
+func TestParseChineseAmount(t *testing.T) {
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"伍分", "0.05"},
+		{"壹拾元伍角整", "10.50"},
+		{"壹亿零壹万元整", "100010000.00"},
+		{"负壹仟元伍角整", "-1000.50"},
+		{"人民币壹万贰仟叁佰肆拾伍元陆角柒分", "12345.67"},
+	}
+	for _, c := range cases {
+		got, err := ParseChineseAmount(model.ChineseNumberParseRequest{Text: c.text})
+		if err != nil {
+			t.Errorf("ParseChineseAmount(%s) error: %v", c.text, err)
+			continue
+		}
+		if got.Amount != c.want {
+			t.Errorf("ParseChineseAmount(%s) = %s, want %s", c.text, got.Amount, c.want)
+		}
+	}
+}
+
+func TestChineseNumberRoundTrip(t *testing.T) {
+	cases := []struct {
+		text string
+		want int64
+	}{
+		{"零", 0},
+		{"十", 10},
+		{"一千零五", 1005},
+		{"三百五", 350},
+		{"两万三", 23000},
+		{"三亿五", 350000000},
+		{"壹万贰仟", 12000},
+	}
+	for _, c := range cases {
+		got, err := ParseChineseNumber(model.ChineseNumberParseRequest{Text: c.text})
+		if err != nil {
+			t.Errorf("ParseChineseNumber(%s) error: %v", c.text, err)
+			continue
+		}
+		if got.Number != c.want {
+			t.Errorf("ParseChineseNumber(%s) = %d, want %d", c.text, got.Number, c.want)
+		}
+	}
+
+	for _, n := range []int64{0, 10, 105, 1005, 10010, 100010000, 9999999999999999} {
+		number := n
+		converted, err := ConvertNumberToChinese(model.NumberToChineseRequest{Number: &number})
+		if err != nil {
+			t.Errorf("ConvertNumberToChinese(%d) error: %v", n, err)
+			continue
+		}
+		parsed, err := ParseChineseNumber(model.ChineseNumberParseRequest{Text: converted.Converted})
+		if err != nil || parsed.Number != n {
+			t.Errorf("round trip %d -> %s -> %v (%v)", n, converted.Converted, parsed, err)
+		}
+	}
+}
+
+func TestConvertNumberToChineseOutOfRange(t *testing.T) {
+	for _, n := range []int64{math.MinInt64, math.MaxInt64, -10000000000000000, 10000000000000000} {
+		number := n
+		if converted, err := ConvertNumberToChinese(model.NumberToChineseRequest{Number: &number}); err == nil {
+			t.Errorf("ConvertNumberToChinese(%d) = %s, expected error", n, converted.Converted)
+		}
+	}
+	number := int64(-9999999999999999)
+	if _, err := ConvertNumberToChinese(model.NumberToChineseRequest{Number: &number}); err != nil {
+		t.Errorf("ConvertNumberToChinese(%d) error: %v", number, err)
+	}
+}
@@ -0,0 +1,600 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 默认返回的触发次数及上限
+const (
+	defaultCronCount = 5
+	maxCronCount     = 100
+)
+
+// 查找下次触发时间的最大年数跨度
+const maxCronSearchYears = 10
+
+// Cron宏定义
+var CRON_MACROS = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// 英文月份名称
+var monthNamesEn = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+// 英文星期名称
+var weekdayNamesEn = [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// Cron字段定义
+type cronFieldSpec struct {
+	nameZh  string
+	unitZh  string // 用于"每隔N…"
+	unitEn  string
+	min     int
+	max     int
+	aliases []string // 名称别名，下标对应取值（从min开始）
+	valueZh func(int) string
+	valueEn func(int) string
+}
+
+var (
+	cronSecondSpec = cronFieldSpec{
+		nameZh: "秒", unitZh: "秒", unitEn: "second", min: 0, max: 59,
+		valueZh: func(v int) string { return fmt.Sprintf("%d秒", v) },
+		valueEn: strconv.Itoa,
+	}
+	cronMinuteSpec = cronFieldSpec{
+		nameZh: "分钟", unitZh: "分钟", unitEn: "minute", min: 0, max: 59,
+		valueZh: func(v int) string { return fmt.Sprintf("%d分", v) },
+		valueEn: strconv.Itoa,
+	}
+	cronHourSpec = cronFieldSpec{
+		nameZh: "小时", unitZh: "小时", unitEn: "hour", min: 0, max: 23,
+		valueZh: func(v int) string { return fmt.Sprintf("%d点", v) },
+		valueEn: strconv.Itoa,
+	}
+	cronDaySpec = cronFieldSpec{
+		nameZh: "日期", unitZh: "天", unitEn: "day-of-month", min: 1, max: 31,
+		valueZh: func(v int) string { return fmt.Sprintf("%d日", v) },
+		valueEn: strconv.Itoa,
+	}
+	cronMonthSpec = cronFieldSpec{
+		nameZh: "月份", unitZh: "个月", unitEn: "month", min: 1, max: 12,
+		aliases: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"},
+		valueZh: func(v int) string { return fmt.Sprintf("%d月", v) },
+		valueEn: func(v int) string { return monthNamesEn[v-1] },
+	}
+	cronWeekdaySpec = cronFieldSpec{
+		nameZh: "星期", unitZh: "天", unitEn: "day-of-week", min: 0, max: 7, // 0和7均表示周日
+		aliases: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+		valueZh: func(v int) string { return utils.WeekdayNames[v%7] },
+		valueEn: func(v int) string { return weekdayNamesEn[v%7] },
+	}
+)
+
+// Cron字段中逗号分隔的一项，如 5、1-5、*/15、10-30/5
+type cronItem struct {
+	start int
+	end   int
+	step  int
+	star  bool // 以*或?开头
+}
+
+// 解析后的Cron字段
+type cronField struct {
+	spec  *cronFieldSpec
+	raw   string
+	items []cronItem
+	bits  uint64
+}
+
+// 解析后的Cron表达式
+type cronSchedule struct {
+	hasSeconds bool
+	second     *cronField
+	minute     *cronField
+	hour       *cronField
+	day        *cronField
+	month      *cronField
+	weekday    *cronField
+}
+
+// 字段是否为任意值（* 或 ?）
+func (f *cronField) isAny() bool {
+	return f.raw == "*" || f.raw == "?"
+}
+
+// 字段是否以*开头（如 *、*/2）或为?，与Vixie cron一致，日期和星期字段据此判断是否有限定
+func (f *cronField) isStarred() bool {
+	return strings.HasPrefix(f.raw, "*") || f.raw == "?"
+}
+
+// 字段是否匹配
+func (f *cronField) matches(v int) bool {
+	return f.bits&(1<<uint(v)) != 0
+}
+
+// 字段是否全部由单个值组成
+func (f *cronField) allSingles() bool {
+	for _, item := range f.items {
+		if item.star || item.start != item.end {
+			return false
+		}
+	}
+	return true
+}
+
+// 解析字段中的单个值（数字或名称别名）
+func parseCronValue(s string, spec *cronFieldSpec) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < spec.min || n > spec.max {
+			return 0, fmt.Errorf("%d超出范围(%d-%d)", n, spec.min, spec.max)
+		}
+		return n, nil
+	}
+	upper := strings.ToUpper(s)
+	for i, alias := range spec.aliases {
+		if upper == alias {
+			return spec.min + i, nil
+		}
+	}
+	return 0, fmt.Errorf("无法识别的值: %s", s)
+}
+
+// 解析Cron字段
+func parseCronField(raw string, spec *cronFieldSpec) (*cronField, error) {
+	field := &cronField{spec: spec, raw: raw}
+	for _, part := range strings.Split(raw, ",") {
+		item := cronItem{step: 1}
+		base := part
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			base = part[:idx]
+			step, err := strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("步长无效: %s", part)
+			}
+			item.step = step
+		}
+
+		switch {
+		case base == "*" || (base == "?" && (spec == &cronDaySpec || spec == &cronWeekdaySpec)):
+			item.start, item.end, item.star = spec.min, spec.max, true
+			if spec == &cronWeekdaySpec {
+				item.end = 6
+			}
+		case strings.Contains(base, "-"):
+			bounds := strings.SplitN(base, "-", 2)
+			start, err := parseCronValue(bounds[0], spec)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseCronValue(bounds[1], spec)
+			if err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, fmt.Errorf("范围起始值大于结束值: %s", base)
+			}
+			item.start, item.end = start, end
+		default:
+			if base == "" {
+				return nil, fmt.Errorf("存在空值: %s", raw)
+			}
+			value, err := parseCronValue(base, spec)
+			if err != nil {
+				if strings.ContainsAny(base, "LW#") {
+					return nil, fmt.Errorf("不支持的语法: %s", base)
+				}
+				return nil, err
+			}
+			item.start, item.end = value, value
+			// a/n 形式表示从a到最大值
+			if strings.Contains(part, "/") {
+				item.end = spec.max
+			}
+		}
+
+		for v := item.start; v <= item.end; v += item.step {
+			bit := v
+			if spec == &cronWeekdaySpec {
+				bit = v % 7
+			}
+			field.bits |= 1 << uint(bit)
+		}
+		field.items = append(field.items, item)
+	}
+	return field, nil
+}
+
+// 解析Cron表达式，支持5字段（分 时 日 月 周）、6字段（秒 分 时 日 月 周）及@daily等宏
+func parseCronExpression(expr string) (*cronSchedule, string, error) {
+	normalized := strings.Join(strings.Fields(expr), " ")
+	if strings.HasPrefix(normalized, "@") {
+		macro, ok := CRON_MACROS[strings.ToLower(normalized)]
+		if !ok {
+			return nil, "", fmt.Errorf("不支持的宏: %s，可选值: @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly", normalized)
+		}
+		normalized = macro
+	}
+
+	fields := strings.Fields(normalized)
+	if len(fields) != 5 && len(fields) != 6 {
+		return nil, "", fmt.Errorf("Cron表达式应为5个字段（分 时 日 月 周）或6个字段（秒 分 时 日 月 周），当前为%d个", len(fields))
+	}
+
+	specs := []*cronFieldSpec{&cronMinuteSpec, &cronHourSpec, &cronDaySpec, &cronMonthSpec, &cronWeekdaySpec}
+	schedule := &cronSchedule{hasSeconds: len(fields) == 6}
+	if schedule.hasSeconds {
+		specs = append([]*cronFieldSpec{&cronSecondSpec}, specs...)
+	} else {
+		// 5字段表达式固定在第0秒触发
+		schedule.second, _ = parseCronField("0", &cronSecondSpec)
+	}
+
+	parsed := make([]*cronField, len(fields))
+	for i, raw := range fields {
+		field, err := parseCronField(raw, specs[i])
+		if err != nil {
+			return nil, "", fmt.Errorf("第%d个字段（%s）无效: %s", i+1, specs[i].nameZh, err.Error())
+		}
+		parsed[i] = field
+	}
+	if schedule.hasSeconds {
+		schedule.second, parsed = parsed[0], parsed[1:]
+	}
+	schedule.minute, schedule.hour, schedule.day, schedule.month, schedule.weekday = parsed[0], parsed[1], parsed[2], parsed[3], parsed[4]
+
+	return schedule, normalized, nil
+}
+
+// 日期和星期是否都有限定（均不以*开头），此时满足其一即可，否则需同时满足（与标准cron一致）
+func (s *cronSchedule) dayOrWeekday() bool {
+	return !s.day.isStarred() && !s.weekday.isStarred()
+}
+
+// 日期是否匹配
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dayMatch := s.day.matches(t.Day())
+	weekdayMatch := s.weekday.matches(int(t.Weekday()))
+	if s.dayOrWeekday() {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// 计算t之后（不含t）的下一次触发时间
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	loc := t.Location()
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Year() + maxCronSearchYears
+
+	for t.Year() <= limit {
+		if !s.month.matches(int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.hour.matches(t.Hour()) {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			// 夏令时回拨时可能得到同一小时，按实际时间前进
+			if !next.After(t) {
+				next = t.Add(time.Hour).Truncate(time.Minute)
+			}
+			t = next
+			continue
+		}
+		if !s.minute.matches(t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if !s.second.matches(t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// 英文序数词
+func ordinalEn(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// 用"、"和"和"连接
+func joinZh(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], "、") + "和" + items[len(items)-1]
+}
+
+// 用", "和" and "连接
+func joinEn(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// 字段中文描述
+func (f *cronField) describeZh() string {
+	if f.isAny() {
+		return "每" + f.spec.unitZh
+	}
+	parts := make([]string, 0, len(f.items))
+	for _, item := range f.items {
+		from, to := f.spec.valueZh(item.start), f.spec.valueZh(item.end)
+		switch {
+		case item.star && item.step > 1:
+			parts = append(parts, fmt.Sprintf("每隔%d%s", item.step, f.spec.unitZh))
+		case item.start == item.end:
+			parts = append(parts, from)
+		case item.step > 1:
+			parts = append(parts, fmt.Sprintf("从%s到%s每隔%d%s", from, to, item.step, f.spec.unitZh))
+		default:
+			parts = append(parts, fmt.Sprintf("从%s到%s", from, to))
+		}
+	}
+	return joinZh(parts)
+}
+
+// 字段英文描述
+func (f *cronField) describeEn() string {
+	if f.isAny() {
+		return "every " + f.spec.unitEn
+	}
+	named := f.spec.aliases != nil
+	singles := make([]string, 0, len(f.items))
+	parts := make([]string, 0, len(f.items))
+	for _, item := range f.items {
+		from, to := f.spec.valueEn(item.start), f.spec.valueEn(item.end)
+		unit := f.spec.unitEn
+		if item.step > 1 {
+			unit = ordinalEn(item.step) + " " + unit
+		}
+		switch {
+		case item.start == item.end:
+			singles = append(singles, from)
+		case item.star:
+			parts = append(parts, "every "+unit)
+		default:
+			parts = append(parts, fmt.Sprintf("every %s from %s through %s", unit, from, to))
+		}
+	}
+	if len(singles) > 0 {
+		prefix := ""
+		if !named {
+			prefix = f.spec.unitEn + " "
+		}
+		parts = append([]string{prefix + joinEn(singles)}, parts...)
+	}
+	return joinEn(parts)
+}
+
+// 时分秒是否为固定时刻，返回固定时刻列表（如 09:00、18:30）
+func (s *cronSchedule) fixedTimes() []string {
+	if !s.second.allSingles() || !s.minute.allSingles() || !s.hour.allSingles() {
+		return nil
+	}
+	if len(s.second.items)*len(s.minute.items)*len(s.hour.items) > 6 {
+		return nil
+	}
+	times := []string{}
+	for _, h := range s.hour.items {
+		for _, m := range s.minute.items {
+			for _, sec := range s.second.items {
+				if s.hasSeconds && sec.start != 0 {
+					times = append(times, fmt.Sprintf("%02d:%02d:%02d", h.start, m.start, sec.start))
+				} else {
+					times = append(times, fmt.Sprintf("%02d:%02d", h.start, m.start))
+				}
+			}
+		}
+	}
+	return times
+}
+
+// 生成中文说明
+func (s *cronSchedule) describeZh() string {
+	var b strings.Builder
+	if !s.month.isAny() {
+		b.WriteString(s.month.describeZh() + "的")
+	}
+	dayPrefix, weekdayPrefix := "每月", "每"
+	if !s.month.isAny() {
+		dayPrefix = ""
+	}
+	if !s.weekday.allSingles() {
+		weekdayPrefix = "每周"
+	}
+	switch {
+	case s.day.isAny() && s.weekday.isAny():
+		b.WriteString("每天")
+	case s.weekday.isAny():
+		b.WriteString(dayPrefix + s.day.describeZh())
+	case s.day.isAny():
+		b.WriteString(weekdayPrefix + s.weekday.describeZh())
+	case !s.dayOrWeekday():
+		b.WriteString(dayPrefix + s.day.describeZh() + "且为" + weekdayPrefix + s.weekday.describeZh())
+	default:
+		b.WriteString(dayPrefix + s.day.describeZh() + "或" + weekdayPrefix + s.weekday.describeZh())
+	}
+
+	if times := s.fixedTimes(); times != nil {
+		b.WriteString("的" + joinZh(times))
+		return b.String() + "执行"
+	}
+
+	// 较低字段为任意值时省略较高字段的"每…"
+	parts := []string{}
+	if !(s.hour.isAny() && s.minute.isAny()) {
+		parts = append(parts, s.hour.describeZh())
+	}
+	if !(s.minute.isAny() && s.second.isAny()) {
+		parts = append(parts, s.minute.describeZh())
+	}
+	if s.hasSeconds {
+		parts = append(parts, s.second.describeZh())
+	}
+	b.WriteString("，" + strings.Join(parts, "的"))
+	return b.String() + "执行"
+}
+
+// 生成英文说明
+func (s *cronSchedule) describeEn() string {
+	var b strings.Builder
+	if times := s.fixedTimes(); times != nil {
+		b.WriteString("At " + joinEn(times))
+	} else {
+		parts := []string{}
+		if s.hasSeconds {
+			parts = append(parts, s.second.describeEn())
+		}
+		if !(s.minute.isAny() && s.second.isAny()) || !s.hasSeconds {
+			parts = append(parts, s.minute.describeEn())
+		}
+		if !s.hour.isAny() {
+			parts = append(parts, s.hour.describeEn())
+		}
+		b.WriteString("At " + strings.Join(parts, " past "))
+	}
+
+	if !s.day.isAny() {
+		b.WriteString(" on " + s.day.describeEn())
+	}
+	if !s.weekday.isAny() {
+		switch {
+		case s.day.isAny():
+			b.WriteString(" on " + s.weekday.describeEn())
+		case s.dayOrWeekday():
+			b.WriteString(" and on " + s.weekday.describeEn())
+		default:
+			b.WriteString(" if it's on " + s.weekday.describeEn())
+		}
+	}
+	if !s.month.isAny() {
+		b.WriteString(" in " + s.month.describeEn())
+	}
+	return b.String()
+}
+
+// 解析Cron表达式并计算接下来的触发时间
+func GetCronSchedule(req model.CronRequest) (*model.CronResponse, error) {
+	schedule, normalized, err := parseCronExpression(req.Expression)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3009, Message: err.Error()}
+	}
+
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = defaultCronCount
+	}
+	if count > maxCronCount {
+		return nil, &model.ErrorResponse{Code: 3009, Message: fmt.Sprintf("count不能超过%d", maxCronCount)}
+	}
+
+	// 从开始时间之后计算，默认当前时间
+	start := time.Now().In(loc)
+	if req.StartTime != nil {
+		start, _, err = parseTimeInput(req.StartTime, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3009, Message: "开始时间" + err.Error()}
+		}
+		start = start.In(loc)
+	}
+
+	// 仅工作日触发时使用与工作日计算相同的规则
+	var rule *workdayRule
+	if req.WorkdaysOnly {
+		rule, err = newWorkdayRule(req.WorkdayOptions)
+		if err != nil {
+			return nil, workdayRuleError(err, 3009)
+		}
+	}
+
+	format := req.OutputFormat
+	if format == "" {
+		format = "iso"
+	}
+	if err := checkOutputFormat(format); err != nil {
+		return nil, &model.ErrorResponse{Code: 3009, Message: err.Error()}
+	}
+
+	runs := make([]model.CronRunItem, 0, count)
+	cursor := start
+	for len(runs) < count {
+		next, ok := schedule.next(cursor)
+		if !ok {
+			break
+		}
+		if rule != nil {
+			day := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+			if rule.classify(day).IsRest {
+				// 跳到下一天零点前一秒继续查找
+				cursor = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc).Add(-time.Second)
+				if cursor.Year() > start.Year()+maxCronSearchYears {
+					break
+				}
+				continue
+			}
+		}
+		runs = append(runs, model.CronRunItem{
+			Time:        formatTime(next, format, req.CustomFormat),
+			Timestamp:   next.Unix(),
+			WeekdayName: utils.WeekdayNames[next.Weekday()],
+		})
+		cursor = next
+	}
+	if len(runs) == 0 {
+		return nil, &model.ErrorResponse{Code: 3009, Message: fmt.Sprintf("表达式在%d年内不会触发", maxCronSearchYears)}
+	}
+
+	fields := []string{schedule.minute.raw, schedule.hour.raw, schedule.day.raw, schedule.month.raw, schedule.weekday.raw}
+	if schedule.hasSeconds {
+		fields = append([]string{schedule.second.raw}, fields...)
+	}
+
+	return &model.CronResponse{
+		Expression:    req.Expression,
+		Normalized:    normalized,
+		Fields:        fields,
+		HasSeconds:    schedule.hasSeconds,
+		DescriptionZh: schedule.describeZh(),
+		DescriptionEn: schedule.describeEn(),
+		StartTime:     start.Format(time.RFC3339),
+		NextRuns:      runs,
+		WorkdaysOnly:  req.WorkdaysOnly,
+		Timezone:      timezoneInfo.Name,
+		TimezoneInfo:  *timezoneInfo,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	cases := []struct {
+		expr  string
+		start string
+		want  []string
+	}{
+		{"0 9 * * 1-5", "2024-01-05 10:00:00", []string{"2024-01-08 09:00:00", "2024-01-09 09:00:00"}},
+		{"*/15 * * * *", "2024-01-01 00:07:00", []string{"2024-01-01 00:15:00", "2024-01-01 00:30:00"}},
+		{"30 0 0 1 * *", "2024-01-31 12:00:00", []string{"2024-02-01 00:00:30", "2024-03-01 00:00:30"}},
+		{"@monthly", "2024-01-15 00:00:00", []string{"2024-02-01 00:00:00", "2024-03-01 00:00:00"}},
+		{"0 0 29 2 *", "2024-03-01 00:00:00", []string{"2028-02-29 00:00:00"}},
+		// 日期和星期都有限定时满足其一即可
+		{"0 0 1,15 * 1", "2024-01-01 00:00:00", []string{"2024-01-08 00:00:00", "2024-01-15 00:00:00", "2024-01-22 00:00:00"}},
+		// 日期以*开头时需同时满足
+		{"0 0 */2 * 1", "2024-01-01 00:00:00", []string{"2024-01-15 00:00:00", "2024-01-29 00:00:00", "2024-02-05 00:00:00"}},
+		{"0 0 L * *", "", nil},
+	}
+	for _, c := range cases {
+		schedule, _, err := parseCronExpression(c.expr)
+		if c.want == nil {
+			if err == nil {
+				t.Errorf("parseCronExpression(%q) expected error", c.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCronExpression(%q) error: %v", c.expr, err)
+			continue
+		}
+		cursor, _ := time.ParseInLocation("2006-01-02 15:04:05", c.start, time.UTC)
+		for _, want := range c.want {
+			next, ok := schedule.next(cursor)
+			if !ok || next.Format("2006-01-02 15:04:05") != want {
+				t.Errorf("%q after %s = %s, want %s", c.expr, cursor.Format("2006-01-02 15:04:05"), next.Format("2006-01-02 15:04:05"), want)
+				break
+			}
+			cursor = next
+		}
+	}
+}
+
+func TestCronScheduleNextAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("时区数据不可用")
+	}
+	schedule, _, err := parseCronExpression("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2024-03-10 02:30在夏令时切换中不存在，下一次为次日
+	start := time.Date(2024, 3, 9, 12, 0, 0, 0, loc)
+	next, _ := schedule.next(start)
+	if got := next.Format("2006-01-02 15:04"); got != "2024-03-11 02:30" {
+		t.Errorf("next across DST = %s, want 2024-03-11 02:30", got)
+	}
+}
+
+func TestCronDescription(t *testing.T) {
+	cases := []struct {
+		expr, zh, en string
+	}{
+		{"0 9 * * 1-5", "每周从周一到周五的09:00执行", "At 09:00 on every day-of-week from Monday through Friday"},
+		{"0 0 1,15 * 1", "每月1日和15日或每周一的00:00执行", "At 00:00 on day-of-month 1 and 15 and on Monday"},
+		{"0 0 */2 * 1", "每月每隔2天且为每周一的00:00执行", "At 00:00 on every 2nd day-of-month if it's on Monday"},
+	}
+	for _, c := range cases {
+		schedule, _, err := parseCronExpression(c.expr)
+		if err != nil {
+			t.Errorf("parseCronExpression(%q) error: %v", c.expr, err)
+			continue
+		}
+		if got := schedule.describeZh(); got != c.zh {
+			t.Errorf("describeZh(%q) = %s, want %s", c.expr, got, c.zh)
+		}
+		if got := schedule.describeEn(); got != c.en {
+			t.Errorf("describeEn(%q) = %s, want %s", c.expr, got, c.en)
+		}
+	}
+}
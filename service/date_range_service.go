@@ -0,0 +1,161 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRangePageSize = 100
+	maxRangePageSize     = 1000
+	maxRangeSteps        = 100000 // 单次请求最多枚举的时间点数（筛选前）
+)
+
+// 步长名称对应的ISO-8601时长
+var RANGE_STEPS = map[string]string{
+	"day":     "P1D",
+	"week":    "P1W",
+	"month":   "P1M",
+	"quarter": "P3M",
+	"year":    "P1Y",
+}
+
+// 第n个时间点：每次都从开始时间计算，避免按月累加时月末日期漂移（如1月31日、2月29日、3月31日）
+func rangeStepAt(start time.Time, step *isoDuration, n int) time.Time {
+	t := addMonthsClamped(start, n*(step.Years*12+step.Months))
+	t = t.AddDate(0, 0, n*step.Days)
+	return t.Add(time.Duration(n) * step.Clock)
+}
+
+// 枚举日期范围，可按工作日、星期筛选并分页
+func EnumerateDateRange(req model.DateRangeRequest) (*model.DateRangeResponse, error) {
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	start, _, err := parseRRuleTime(req.Start, loc)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3015, Message: "开始时间" + err.Error()}
+	}
+	end, endDateOnly, err := parseRRuleTime(req.End, loc)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3015, Message: "结束时间" + err.Error()}
+	}
+	start, end = start.In(loc), end.In(loc)
+	if endDateOnly {
+		// 仅日期时包含当天
+		end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	if end.Before(start) {
+		return nil, &model.ErrorResponse{Code: 3015, Message: "结束时间不能早于开始时间"}
+	}
+
+	// 解析步长
+	stepText := strings.TrimSpace(req.Step)
+	if stepText == "" {
+		stepText = "day"
+	}
+	if iso, ok := RANGE_STEPS[strings.ToLower(stepText)]; ok {
+		stepText = iso
+	}
+	step, err := parseISODuration(stepText)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3015, Message: "步长" + err.Error() + "，或使用day、week、month、quarter、year"}
+	}
+	if step.Negative || (step.Years == 0 && step.Months == 0 && step.Days == 0 && step.Clock <= 0) {
+		return nil, &model.ErrorResponse{Code: 3015, Message: "步长必须大于0"}
+	}
+
+	// 星期筛选
+	weekdays := map[time.Weekday]bool{}
+	for _, weekday := range req.Weekdays {
+		if weekday < 0 || weekday > 6 {
+			return nil, &model.ErrorResponse{Code: 3015, Message: fmt.Sprintf("星期取值错误: %d，应为0-6（0为周日）", weekday)}
+		}
+		weekdays[time.Weekday(weekday)] = true
+	}
+
+	rule, err := newWorkdayRule(req.WorkdayOptions)
+	if err != nil {
+		return nil, workdayRuleError(err, 3015)
+	}
+
+	// 分页参数
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultRangePageSize
+	}
+	if pageSize > maxRangePageSize {
+		return nil, &model.ErrorResponse{Code: 3015, Message: fmt.Sprintf("page_size不能超过%d", maxRangePageSize)}
+	}
+
+	// 按天及以上的步长默认只输出日期
+	format := req.OutputFormat
+	if format == "" {
+		format = "datetime"
+		if step.Clock == 0 {
+			format = "date_only"
+		}
+	}
+	if err := checkOutputFormat(format); err != nil {
+		return nil, &model.ErrorResponse{Code: 3015, Message: err.Error()}
+	}
+
+	// 遍历范围内的时间点，统计筛选后的总数并截取当前页
+	offset := (page - 1) * pageSize
+	items := []model.DateRangeItem{}
+	total := 0
+	for n := 0; ; n++ {
+		if n >= maxRangeSteps {
+			return nil, &model.ErrorResponse{Code: 3015, Message: fmt.Sprintf("范围过大，最多枚举%d个时间点，请增大步长或缩小范围", maxRangeSteps)}
+		}
+		t := rangeStepAt(start, step, n)
+		if t.After(end) {
+			break
+		}
+
+		if len(weekdays) > 0 && !weekdays[t.Weekday()] {
+			continue
+		}
+		day := rule.classify(t)
+		if req.WorkdaysOnly && day.IsRest {
+			continue
+		}
+
+		if total >= offset && len(items) < pageSize {
+			items = append(items, model.DateRangeItem{
+				Index:       total,
+				Value:       formatTime(t, format, req.CustomFormat),
+				Timestamp:   t.Unix(),
+				Weekday:     int(t.Weekday()),
+				WeekdayName: utils.WeekdayNames[t.Weekday()],
+				IsRest:      day.IsRest,
+			})
+		}
+		total++
+	}
+
+	totalPages := (total + pageSize - 1) / pageSize
+
+	return &model.DateRangeResponse{
+		Start:        start.Format(time.RFC3339),
+		End:          end.Truncate(time.Second).Format(time.RFC3339),
+		Step:         step.String(),
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   totalPages,
+		HasMore:      page < totalPages,
+		Items:        items,
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+	}, nil
+}
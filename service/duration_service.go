@@ -0,0 +1,370 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISO-8601时长格式，如 P1Y2M3DT4H5M6S、P2W、-P1D
+var isoDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// 时长各部分的上限：年月日不超过约10000年，时分秒合计不超过time.Duration的表示范围（约292年）
+const (
+	maxDurationYears  = 10000
+	maxDurationMonths = maxDurationYears * 12
+	maxDurationDays   = maxDurationYears * 366
+)
+
+var maxDurationSeconds = float64(math.MaxInt64 / int64(time.Second))
+
+// ISO-8601时长，年月日按日历计算，时分秒按实际经过时间计算
+type isoDuration struct {
+	Negative bool
+	Years    int
+	Months   int
+	Days     int // 周数已折算为天
+	Clock    time.Duration
+}
+
+// 解析ISO-8601时长
+func parseISODuration(s string) (*isoDuration, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	matches := isoDurationPattern.FindStringSubmatch(s)
+	if matches == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return nil, fmt.Errorf("时长格式错误: %s，应为ISO-8601格式，如 P1M2DT3H", s)
+	}
+
+	atoi := func(v, unit string, limit int) (int, error) {
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n > limit {
+			return 0, fmt.Errorf("时长的%s部分超出范围: %s，最大为%d", unit, v, limit)
+		}
+		return n, nil
+	}
+	atof := func(v, unit string, scale float64) (float64, error) {
+		if v == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil || f*scale > maxDurationSeconds {
+			return 0, fmt.Errorf("时长的%s部分超出范围: %s", unit, v)
+		}
+		return f * scale, nil
+	}
+
+	d := &isoDuration{Negative: matches[1] == "-"}
+	var weeks, days int
+	var err error
+	if d.Years, err = atoi(matches[2], "年", maxDurationYears); err != nil {
+		return nil, err
+	}
+	if d.Months, err = atoi(matches[3], "月", maxDurationMonths); err != nil {
+		return nil, err
+	}
+	if weeks, err = atoi(matches[4], "周", maxDurationDays/7); err != nil {
+		return nil, err
+	}
+	if days, err = atoi(matches[5], "天", maxDurationDays); err != nil {
+		return nil, err
+	}
+	d.Days = weeks*7 + days
+	if d.Days > maxDurationDays {
+		return nil, fmt.Errorf("时长的周和天部分超出范围，合计最多%d天", maxDurationDays)
+	}
+
+	seconds := 0.0
+	for i, unit := range []struct {
+		name  string
+		scale float64
+	}{{"小时", 3600}, {"分钟", 60}, {"秒", 1}} {
+		v, err := atof(matches[6+i], unit.name, unit.scale)
+		if err != nil {
+			return nil, err
+		}
+		seconds += v
+	}
+	if seconds > maxDurationSeconds {
+		return nil, fmt.Errorf("时长的时分秒部分超出范围，合计最多%.0f秒", maxDurationSeconds)
+	}
+	d.Clock = time.Duration(math.Round(seconds * float64(time.Second)))
+	return d, nil
+}
+
+// 格式化为ISO-8601时长
+func (d *isoDuration) String() string {
+	var b strings.Builder
+	if d.Negative {
+		b.WriteString("-")
+	}
+	b.WriteString("P")
+	if d.Years > 0 {
+		b.WriteString(fmt.Sprintf("%dY", d.Years))
+	}
+	if d.Months > 0 {
+		b.WriteString(fmt.Sprintf("%dM", d.Months))
+	}
+	if d.Days > 0 {
+		b.WriteString(fmt.Sprintf("%dD", d.Days))
+	}
+	if d.Clock > 0 {
+		b.WriteString("T")
+		hours := int64(d.Clock / time.Hour)
+		minutes := int64((d.Clock % time.Hour) / time.Minute)
+		seconds := float64(d.Clock%time.Minute) / float64(time.Second)
+		if hours > 0 {
+			b.WriteString(fmt.Sprintf("%dH", hours))
+		}
+		if minutes > 0 {
+			b.WriteString(fmt.Sprintf("%dM", minutes))
+		}
+		if seconds > 0 {
+			b.WriteString(strconv.FormatFloat(seconds, 'f', -1, 64) + "S")
+		}
+	}
+	if b.Len() <= 2 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// 增加月份，日期超出目标月份天数时取月末（如1月31日加1个月为2月末）
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// 在时间上应用时长（sign为1加，为-1减）
+func applyISODuration(t time.Time, d *isoDuration, sign int) time.Time {
+	if d.Negative {
+		sign = -sign
+	}
+	t = addMonthsClamped(t, sign*(d.Years*12+d.Months))
+	t = t.AddDate(0, 0, sign*d.Days)
+	return t.Add(time.Duration(sign) * d.Clock)
+}
+
+// 日历差值：start不晚于end
+type calendarDiff struct {
+	Years   int
+	Months  int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds int
+}
+
+// 按日历计算两个时间的差值（年、月、日、时、分、秒）
+func calculateCalendarDiff(start, end time.Time) calendarDiff {
+	// 先按月计算，月末日期自动对齐
+	totalMonths := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	cursor := addMonthsClamped(start, totalMonths)
+	for totalMonths > 0 && cursor.After(end) {
+		totalMonths--
+		cursor = addMonthsClamped(start, totalMonths)
+	}
+
+	// 再按天计算（保持墙上时间，夏令时切换日按日历天计）
+	days := 0
+	for {
+		next := cursor.AddDate(0, 0, 1)
+		if next.After(end) {
+			break
+		}
+		cursor = next
+		days++
+	}
+
+	remaining := end.Sub(cursor)
+	return calendarDiff{
+		Years:   totalMonths / 12,
+		Months:  totalMonths % 12,
+		Days:    days,
+		Hours:   int(remaining / time.Hour),
+		Minutes: int((remaining % time.Hour) / time.Minute),
+		Seconds: int((remaining % time.Minute) / time.Second),
+	}
+}
+
+// 格式化日历差值为中文描述
+func (d calendarDiff) description() string {
+	parts := []struct {
+		value int
+		unit  string
+	}{
+		{d.Years, "年"}, {d.Months, "个月"}, {d.Days, "天"},
+		{d.Hours, "小时"}, {d.Minutes, "分钟"}, {d.Seconds, "秒"},
+	}
+	var b strings.Builder
+	for _, part := range parts {
+		if part.value > 0 {
+			b.WriteString(strconv.Itoa(part.value) + part.unit)
+		}
+	}
+	if b.Len() == 0 {
+		return "0秒"
+	}
+	return b.String()
+}
+
+// 解析时间差和加减中的时间，除parseTimeInput支持的格式外，仅日期（如2024-01-01、2024/01/01）按当天零点处理
+func parseDurationTimeInput(input interface{}, loc *time.Location) (time.Time, string, error) {
+	if s, ok := input.(string); ok {
+		for _, layout := range []string{"2006-01-02", "2006/01/02"} {
+			if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), loc); err == nil {
+				return t, s, nil
+			}
+		}
+	}
+	return parseTimeInput(input, loc)
+}
+
+// 计算两个时间的差值
+func CalculateTimeDiff(req model.TimeDiffRequest) (*model.TimeDiffResponse, error) {
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	// 不带时区的时间字符串按指定时区解析
+	start, _, err := parseDurationTimeInput(req.StartTime, loc)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3008, Message: "开始时间" + err.Error()}
+	}
+	end := time.Now()
+	if req.EndTime != nil {
+		end, _, err = parseDurationTimeInput(req.EndTime, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3008, Message: "结束时间" + err.Error()}
+		}
+	}
+	start = start.In(loc)
+	end = end.In(loc)
+
+	// 结束时间早于开始时间时结果为负
+	negative := end.Before(start)
+	from, to := start, end
+	if negative {
+		from, to = end, start
+	}
+
+	diff := calculateCalendarDiff(from, to)
+	// 按秒数计算总时长，time.Duration最多约292年，不能直接相减
+	elapsedSeconds := to.Unix() - from.Unix()
+	nanos := to.Nanosecond() - from.Nanosecond()
+	if nanos < 0 {
+		elapsedSeconds--
+		nanos += int(time.Second)
+	}
+	elapsed := float64(elapsedSeconds) + float64(nanos)/float64(time.Second)
+	sign := 1
+	if negative {
+		sign = -1
+	}
+
+	iso := &isoDuration{
+		Negative: negative,
+		Years:    diff.Years,
+		Months:   diff.Months,
+		Days:     diff.Days,
+		Clock:    time.Duration(diff.Hours)*time.Hour + time.Duration(diff.Minutes)*time.Minute + time.Duration(diff.Seconds)*time.Second,
+	}
+	description := diff.description()
+	if negative {
+		description = "-" + description
+	}
+
+	return &model.TimeDiffResponse{
+		StartTime:    start.Format(time.RFC3339),
+		EndTime:      end.Format(time.RFC3339),
+		Negative:     negative,
+		Years:        sign * diff.Years,
+		Months:       sign * diff.Months,
+		Days:         sign * diff.Days,
+		Hours:        sign * diff.Hours,
+		Minutes:      sign * diff.Minutes,
+		Seconds:      sign * diff.Seconds,
+		TotalMonths:  sign * (diff.Years*12 + diff.Months),
+		TotalWeeks:   math.Round(float64(sign)*elapsed/86400/7*100) / 100,
+		TotalDays:    math.Round(float64(sign)*elapsed/86400*10000) / 10000,
+		TotalHours:   math.Round(float64(sign)*elapsed/3600*10000) / 10000,
+		TotalMinutes: math.Round(float64(sign)*elapsed/60*100) / 100,
+		TotalSeconds: int64(sign) * elapsedSeconds,
+		ISODuration:  iso.String(),
+		Description:  description,
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+	}, nil
+}
+
+// 时间加减ISO-8601时长
+func AddTimeDuration(req model.TimeAddRequest) (*model.TimeAddResponse, error) {
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	duration, err := parseISODuration(req.Duration)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3008, Message: err.Error()}
+	}
+
+	// 解析时间，默认当前时间
+	input := time.Now()
+	originalStr := ""
+	if req.TimeInput != nil {
+		input, originalStr, err = parseDurationTimeInput(req.TimeInput, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3008, Message: err.Error()}
+		}
+	}
+	input = input.In(loc)
+	if originalStr == "" {
+		originalStr = input.Format(time.RFC3339)
+	}
+
+	sign := 1
+	operation := req.Operation
+	switch operation {
+	case "", "add":
+		operation = "add"
+	case "subtract":
+		sign = -1
+	default:
+		return nil, &model.ErrorResponse{Code: 3008, Message: "不支持的操作: " + req.Operation + "，可选值: add, subtract"}
+	}
+
+	result := applyISODuration(input, duration, sign)
+
+	// 格式化输出
+	format := req.OutputFormat
+	if format == "" {
+		format = "iso"
+	}
+	if err := checkOutputFormat(format); err != nil {
+		return nil, &model.ErrorResponse{Code: 3008, Message: err.Error()}
+	}
+
+	return &model.TimeAddResponse{
+		Original:     originalStr,
+		Duration:     duration.String(),
+		Operation:    operation,
+		Result:       formatTime(result, format, req.CustomFormat),
+		ResultISO:    result.Format(time.RFC3339),
+		Timestamp:    result.Unix(),
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+	}, nil
+}
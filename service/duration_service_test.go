@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/renoz/toolbox-api/model"
+	"testing"
+)
+
+// 跨度超过time.Duration上限（约292年）时总量仍应正确
+func TestCalculateTimeDiffLongSpan(t *testing.T) {
+	resp, err := CalculateTimeDiff(model.TimeDiffRequest{StartTime: "1700-01-01", EndTime: "2024-01-01", Timezone: "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Years != 324 || resp.TotalDays != 118338 || resp.TotalSeconds != 118338*86400 {
+		t.Errorf("diff = %d years, %v days, %d seconds, want 324 years, 118338 days, %d seconds", resp.Years, resp.TotalDays, resp.TotalSeconds, 118338*86400)
+	}
+
+	resp, err = CalculateTimeDiff(model.TimeDiffRequest{StartTime: "2024-01-01 00:00:00.5", EndTime: "1700-01-01", Timezone: "UTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Negative || resp.TotalSeconds != -118338*86400 {
+		t.Errorf("reverse diff = negative %v, %d seconds, want true, %d", resp.Negative, resp.TotalSeconds, -118338*86400)
+	}
+}
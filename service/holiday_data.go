@@ -0,0 +1,76 @@
+package service
+
+// 中国法定节假日数据版本（每年国务院办公厅发布通知后更新）
+const CN_HOLIDAY_DATA_VERSION = "2026.1"
+
+// 节假日放假区间
+type holidayPeriod struct {
+	Name     string   // 节日名称
+	Start    string   // 放假开始日期
+	End      string   // 放假结束日期
+	Workdays []string // 调休上班日期
+}
+
+// 中国法定节假日及调休安排（数据来源：国务院办公厅节假日安排通知）
+var CN_HOLIDAYS = map[int][]holidayPeriod{
+	2020: {
+		{Name: "元旦", Start: "2020-01-01", End: "2020-01-01"},
+		{Name: "春节", Start: "2020-01-24", End: "2020-02-02", Workdays: []string{"2020-01-19"}},
+		{Name: "清明节", Start: "2020-04-04", End: "2020-04-06"},
+		{Name: "劳动节", Start: "2020-05-01", End: "2020-05-05", Workdays: []string{"2020-04-26", "2020-05-09"}},
+		{Name: "端午节", Start: "2020-06-25", End: "2020-06-27", Workdays: []string{"2020-06-28"}},
+		{Name: "国庆节、中秋节", Start: "2020-10-01", End: "2020-10-08", Workdays: []string{"2020-09-27", "2020-10-10"}},
+	},
+	2021: {
+		{Name: "元旦", Start: "2021-01-01", End: "2021-01-03"},
+		{Name: "春节", Start: "2021-02-11", End: "2021-02-17", Workdays: []string{"2021-02-07", "2021-02-20"}},
+		{Name: "清明节", Start: "2021-04-03", End: "2021-04-05"},
+		{Name: "劳动节", Start: "2021-05-01", End: "2021-05-05", Workdays: []string{"2021-04-25", "2021-05-08"}},
+		{Name: "端午节", Start: "2021-06-12", End: "2021-06-14"},
+		{Name: "中秋节", Start: "2021-09-19", End: "2021-09-21", Workdays: []string{"2021-09-18"}},
+		{Name: "国庆节", Start: "2021-10-01", End: "2021-10-07", Workdays: []string{"2021-09-26", "2021-10-09"}},
+	},
+	2022: {
+		{Name: "元旦", Start: "2022-01-01", End: "2022-01-03"},
+		{Name: "春节", Start: "2022-01-31", End: "2022-02-06", Workdays: []string{"2022-01-29", "2022-01-30"}},
+		{Name: "清明节", Start: "2022-04-03", End: "2022-04-05", Workdays: []string{"2022-04-02"}},
+		{Name: "劳动节", Start: "2022-04-30", End: "2022-05-04", Workdays: []string{"2022-04-24", "2022-05-07"}},
+		{Name: "端午节", Start: "2022-06-03", End: "2022-06-05"},
+		{Name: "中秋节", Start: "2022-09-10", End: "2022-09-12"},
+		{Name: "国庆节", Start: "2022-10-01", End: "2022-10-07", Workdays: []string{"2022-10-08", "2022-10-09"}},
+	},
+	2023: {
+		{Name: "元旦", Start: "2022-12-31", End: "2023-01-02"},
+		{Name: "春节", Start: "2023-01-21", End: "2023-01-27", Workdays: []string{"2023-01-28", "2023-01-29"}},
+		{Name: "清明节", Start: "2023-04-05", End: "2023-04-05"},
+		{Name: "劳动节", Start: "2023-04-29", End: "2023-05-03", Workdays: []string{"2023-04-23", "2023-05-06"}},
+		{Name: "端午节", Start: "2023-06-22", End: "2023-06-24", Workdays: []string{"2023-06-25"}},
+		{Name: "中秋节、国庆节", Start: "2023-09-29", End: "2023-10-06", Workdays: []string{"2023-10-07", "2023-10-08"}},
+	},
+	2024: {
+		{Name: "元旦", Start: "2024-01-01", End: "2024-01-01"},
+		{Name: "春节", Start: "2024-02-10", End: "2024-02-17", Workdays: []string{"2024-02-04", "2024-02-18"}},
+		{Name: "清明节", Start: "2024-04-04", End: "2024-04-06", Workdays: []string{"2024-04-07"}},
+		{Name: "劳动节", Start: "2024-05-01", End: "2024-05-05", Workdays: []string{"2024-04-28", "2024-05-11"}},
+		{Name: "端午节", Start: "2024-06-10", End: "2024-06-10"},
+		{Name: "中秋节", Start: "2024-09-15", End: "2024-09-17", Workdays: []string{"2024-09-14"}},
+		{Name: "国庆节", Start: "2024-10-01", End: "2024-10-07", Workdays: []string{"2024-09-29", "2024-10-12"}},
+	},
+	2025: {
+		{Name: "元旦", Start: "2025-01-01", End: "2025-01-01"},
+		{Name: "春节", Start: "2025-01-28", End: "2025-02-04", Workdays: []string{"2025-01-26", "2025-02-08"}},
+		{Name: "清明节", Start: "2025-04-04", End: "2025-04-06"},
+		{Name: "劳动节", Start: "2025-05-01", End: "2025-05-05", Workdays: []string{"2025-04-27"}},
+		{Name: "端午节", Start: "2025-05-31", End: "2025-06-02"},
+		{Name: "国庆节、中秋节", Start: "2025-10-01", End: "2025-10-08", Workdays: []string{"2025-09-28", "2025-10-11"}},
+	},
+	2026: {
+		{Name: "元旦", Start: "2026-01-01", End: "2026-01-03", Workdays: []string{"2026-01-04"}},
+		{Name: "春节", Start: "2026-02-15", End: "2026-02-23", Workdays: []string{"2026-02-14", "2026-02-28"}},
+		{Name: "清明节", Start: "2026-04-04", End: "2026-04-06"},
+		{Name: "劳动节", Start: "2026-05-01", End: "2026-05-05", Workdays: []string{"2026-05-09"}},
+		{Name: "端午节", Start: "2026-06-19", End: "2026-06-21"},
+		{Name: "中秋节", Start: "2026-09-25", End: "2026-09-27"},
+		{Name: "国庆节", Start: "2026-10-01", End: "2026-10-07", Workdays: []string{"2026-09-20", "2026-10-10"}},
+	},
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 休息日类型
+const (
+	REST_TYPE_WEEKEND = "weekend" // 周末（休息日模式）
+	REST_TYPE_HOLIDAY = "holiday" // 法定节假日
+	REST_TYPE_EXCLUDE = "exclude" // 自定义排除日期
+)
+
+// 内置节假日日历
+const CALENDAR_CN = "cn"
+
+// 中国节假日日期索引（按需构建）
+var (
+	cnHolidayDates     map[string]string // 放假日期 -> 节日名称
+	cnWorkdayDates     map[string]string // 调休上班日期 -> 说明
+	cnHolidayIndexOnce sync.Once
+)
+
+// 构建中国节假日日期索引
+func buildCnHolidayIndex() {
+	cnHolidayDates = make(map[string]string)
+	cnWorkdayDates = make(map[string]string)
+
+	for _, periods := range CN_HOLIDAYS {
+		for _, period := range periods {
+			start, err := time.Parse("2006-01-02", period.Start)
+			if err != nil {
+				continue
+			}
+			end, err := time.Parse("2006-01-02", period.End)
+			if err != nil {
+				continue
+			}
+			for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+				cnHolidayDates[d.Format("2006-01-02")] = period.Name
+			}
+			for _, workday := range period.Workdays {
+				cnWorkdayDates[workday] = period.Name + "调休上班"
+			}
+		}
+	}
+}
+
+// 工作日判定规则
+type workdayRule struct {
+	restDayPattern string
+	calendar       string
+	holidays       map[string]string // 休息日期 -> 节日名称
+	workdays       map[string]string // 上班日期 -> 说明
+	excludeDates   map[string]bool
+	addDates       map[string]bool
+}
+
+// 日期分类结果
+type dayClass struct {
+	IsRest   bool
+	RestType string
+	Reason   string
+}
+
+// 创建工作日判定规则
+func newWorkdayRule(opts model.WorkdayOptions) (*workdayRule, error) {
+	restDayPattern := opts.RestDayPattern
+	calendar := opts.Calendar
+
+	// 加载自定义日历，未指定内置日历和休息日模式时沿用自定义日历的设置
+	var custom *model.Calendar
+	if opts.CalendarID != "" {
+		cal, err := getCalendar(opts.CalendarID)
+		if err != nil {
+			return nil, err
+		}
+		custom = cal
+		if calendar == "" {
+			calendar = custom.BaseCalendar
+		}
+		if restDayPattern == "" {
+			restDayPattern = custom.RestDayPattern
+		}
+	}
+
+	// 默认休息日模式: 周六日休息
+	if restDayPattern == "" {
+		restDayPattern = "0000011"
+	}
+
+	// 验证休息日模式：7位，仅包含0和1，周一到周日
+	if !isValidRestDayPattern(restDayPattern) {
+		return nil, fmt.Errorf("休息日模式格式错误，应为7位0/1字符串（周一到周日，1表示休息）")
+	}
+
+	rule := &workdayRule{
+		restDayPattern: restDayPattern,
+		calendar:       calendar,
+		excludeDates:   make(map[string]bool),
+		addDates:       make(map[string]bool),
+	}
+
+	switch calendar {
+	case "":
+	case CALENDAR_CN:
+		cnHolidayIndexOnce.Do(buildCnHolidayIndex)
+		rule.holidays = cnHolidayDates
+		rule.workdays = cnWorkdayDates
+	default:
+		return nil, fmt.Errorf("不支持的节假日日历: %s，可选值: %s", calendar, CALENDAR_CN)
+	}
+
+	// 自定义日历覆盖内置日历，复制一份避免修改共享索引
+	if custom != nil {
+		holidays := make(map[string]string, len(rule.holidays)+len(custom.Holidays))
+		workdays := make(map[string]string, len(rule.workdays)+len(custom.Workdays))
+		for date, name := range rule.holidays {
+			holidays[date] = name
+		}
+		for date, name := range rule.workdays {
+			workdays[date] = name
+		}
+		for _, day := range custom.Holidays {
+			delete(workdays, day.Date)
+			holidays[day.Date] = day.Name
+		}
+		for _, day := range custom.Workdays {
+			delete(holidays, day.Date)
+			workdays[day.Date] = day.Name
+		}
+		rule.holidays = holidays
+		rule.workdays = workdays
+	}
+
+	for _, dateStr := range opts.ExcludeDates {
+		rule.excludeDates[dateStr] = true
+	}
+	for _, dateStr := range opts.AddDates {
+		rule.addDates[dateStr] = true
+	}
+
+	return rule, nil
+}
+
+// 将工作日规则错误转换为错误响应，自定义日历错误保留原错误码
+func workdayRuleError(err error, code int) error {
+	if e, ok := err.(*model.ErrorResponse); ok {
+		return e
+	}
+	return &model.ErrorResponse{Code: code, Message: err.Error()}
+}
+
+// 验证休息日模式
+func isValidRestDayPattern(pattern string) bool {
+	return len(pattern) == 7 && strings.Trim(pattern, "01") == ""
+}
+
+// 判断日期是否为休息日，并给出原因
+// 优先级：休息日模式 < 节假日日历 < 自定义排除日期 < 自定义添加日期
+func (r *workdayRule) classify(date time.Time) dayClass {
+	dateStr := date.Format("2006-01-02")
+
+	// 调整为周一=0 ... 周日=6 的索引
+	weekday := int(date.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	weekday--
+
+	result := dayClass{}
+	if r.restDayPattern[weekday] == '1' {
+		result = dayClass{IsRest: true, RestType: REST_TYPE_WEEKEND, Reason: "周末"}
+	}
+
+	if name, ok := r.holidays[dateStr]; ok {
+		if name == "" {
+			name = "节假日"
+		}
+		result = dayClass{IsRest: true, RestType: REST_TYPE_HOLIDAY, Reason: name}
+	} else if name, ok := r.workdays[dateStr]; ok {
+		if name == "" {
+			name = "调休上班"
+		}
+		result = dayClass{IsRest: false, Reason: name}
+	}
+
+	if r.excludeDates[dateStr] {
+		result = dayClass{IsRest: true, RestType: REST_TYPE_EXCLUDE, Reason: "自定义排除"}
+	}
+
+	if r.addDates[dateStr] {
+		result = dayClass{IsRest: false, Reason: "自定义工作日"}
+	}
+
+	return result
+}
+
+// 返回日期范围内节假日日历未覆盖的年份
+func (r *workdayRule) missingYears(startDate, endDate time.Time) []int {
+	if r.calendar != CALENDAR_CN {
+		return nil
+	}
+	var years []int
+	for year := startDate.Year(); year <= endDate.Year(); year++ {
+		if _, ok := CN_HOLIDAYS[year]; !ok {
+			years = append(years, year)
+		}
+	}
+	return years
+}
+
+// 获取指定年份的法定节假日安排
+func GetHolidays(calendar string, year int) (*model.HolidayListResponse, error) {
+	if calendar == "" {
+		calendar = CALENDAR_CN
+	}
+	if calendar != CALENDAR_CN {
+		return nil, &model.ErrorResponse{Code: 3003, Message: "不支持的节假日日历: " + calendar + "，可选值: " + CALENDAR_CN}
+	}
+
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	periods, ok := CN_HOLIDAYS[year]
+	if !ok {
+		// 列出已支持的年份
+		var years []int
+		for y := range CN_HOLIDAYS {
+			years = append(years, y)
+		}
+		sort.Ints(years)
+		return nil, &model.ErrorResponse{Code: 3003, Message: fmt.Sprintf("暂无%d年的节假日数据，已支持年份: %v", year, years)}
+	}
+
+	holidays := make([]model.HolidayItem, 0, len(periods))
+	for _, period := range periods {
+		start, _ := time.Parse("2006-01-02", period.Start)
+		end, _ := time.Parse("2006-01-02", period.End)
+		workdays := period.Workdays
+		if workdays == nil {
+			workdays = []string{}
+		}
+		holidays = append(holidays, model.HolidayItem{
+			Name:     period.Name,
+			Start:    period.Start,
+			End:      period.End,
+			Days:     int(end.Sub(start).Hours()/24) + 1,
+			Workdays: workdays,
+		})
+	}
+
+	return &model.HolidayListResponse{
+		Calendar: calendar,
+		Version:  CN_HOLIDAY_DATA_VERSION,
+		Year:     year,
+		Holidays: holidays,
+	}, nil
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"time"
+)
+
+// 农历日期
+type lunarDate struct {
+	Year      int
+	Month     int
+	Day       int
+	IsLeap    bool
+	MonthDays int // 当月天数
+	LeapMonth int // 当年闰月，0表示无闰月
+}
+
+// 农历某年的闰月月份，0表示无闰月
+func lunarLeapMonth(year int) int {
+	return LUNAR_INFO[year-LUNAR_MIN_YEAR] & 0xf
+}
+
+// 农历某年闰月的天数
+func lunarLeapDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	}
+	if LUNAR_INFO[year-LUNAR_MIN_YEAR]&0x10000 != 0 {
+		return 30
+	}
+	return 29
+}
+
+// 农历某年某月（非闰月）的天数
+func lunarMonthDays(year, month int) int {
+	if LUNAR_INFO[year-LUNAR_MIN_YEAR]&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// 农历某年的总天数
+func lunarYearDays(year int) int {
+	days := 0
+	for month := 1; month <= 12; month++ {
+		days += lunarMonthDays(year, month)
+	}
+	return days + lunarLeapDays(year)
+}
+
+// 农历基准日（1900年正月初一）
+func lunarBase() time.Time {
+	base, _ := time.Parse("2006-01-02", lunarBaseDate)
+	return base
+}
+
+// 公历转农历，日期按当天零点计算
+func solarToLunar(date time.Time) (*lunarDate, error) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := int(date.Sub(lunarBase()).Hours() / 24)
+	if offset < 0 {
+		return nil, fmt.Errorf("日期超出支持范围，最早支持%s", lunarBaseDate)
+	}
+
+	// 逐年扣减
+	year := LUNAR_MIN_YEAR
+	for ; year <= LUNAR_MAX_YEAR; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+	if year > LUNAR_MAX_YEAR {
+		return nil, fmt.Errorf("日期超出支持范围，最晚支持农历%d年", LUNAR_MAX_YEAR)
+	}
+
+	// 逐月扣减，闰月紧跟在同名月份之后
+	leap := lunarLeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		days := lunarMonthDays(year, month)
+		if offset < days {
+			return &lunarDate{Year: year, Month: month, Day: offset + 1, MonthDays: days, LeapMonth: leap}, nil
+		}
+		offset -= days
+
+		if month == leap {
+			days = lunarLeapDays(year)
+			if offset < days {
+				return &lunarDate{Year: year, Month: month, Day: offset + 1, IsLeap: true, MonthDays: days, LeapMonth: leap}, nil
+			}
+			offset -= days
+		}
+	}
+
+	return nil, fmt.Errorf("农历换算失败")
+}
+
+// 农历转公历
+func lunarToSolar(year, month, day int, isLeap bool) (time.Time, error) {
+	if year < LUNAR_MIN_YEAR || year > LUNAR_MAX_YEAR {
+		return time.Time{}, fmt.Errorf("农历年份超出支持范围(%d-%d)", LUNAR_MIN_YEAR, LUNAR_MAX_YEAR)
+	}
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("农历月份必须在1-12之间")
+	}
+	leap := lunarLeapMonth(year)
+	if isLeap && leap != month {
+		if leap == 0 {
+			return time.Time{}, fmt.Errorf("农历%d年没有闰月", year)
+		}
+		return time.Time{}, fmt.Errorf("农历%d年闰月为闰%s，不是闰%s", year, LUNAR_MONTH_NAMES[leap-1], LUNAR_MONTH_NAMES[month-1])
+	}
+
+	monthDays := lunarMonthDays(year, month)
+	if isLeap {
+		monthDays = lunarLeapDays(year)
+	}
+	if day < 1 || day > monthDays {
+		return time.Time{}, fmt.Errorf("农历%d年%s只有%d天", year, lunarMonthName(month, isLeap), monthDays)
+	}
+
+	offset := 0
+	for y := LUNAR_MIN_YEAR; y < year; y++ {
+		offset += lunarYearDays(y)
+	}
+	for m := 1; m < month; m++ {
+		offset += lunarMonthDays(year, m)
+		if m == leap {
+			offset += lunarLeapDays(year)
+		}
+	}
+	if isLeap {
+		offset += lunarMonthDays(year, month)
+	}
+	offset += day - 1
+
+	return lunarBase().AddDate(0, 0, offset), nil
+}
+
+// 农历月份名称，如 闰四月、腊月
+func lunarMonthName(month int, isLeap bool) string {
+	name := LUNAR_MONTH_NAMES[month-1]
+	if isLeap {
+		name = "闰" + name
+	}
+	return name
+}
+
+// 六十甲子序号转干支
+func ganzhiName(index int) string {
+	index = ((index % 60) + 60) % 60
+	return HEAVENLY_STEMS[index%10] + EARTHLY_BRANCHES[index%12]
+}
+
+// 农历年干支（以正月初一为界），甲子年序号为0
+func ganzhiYearIndex(lunarYear int) int {
+	return ((lunarYear-4)%60 + 60) % 60
+}
+
+// 月干支（以节气中的"节"为界），1900年小寒起为丁丑月
+func ganzhiMonthIndex(date time.Time) int {
+	year := date.Year()
+	dateStr := date.Format("2006-01-02")
+
+	// 找到当天之前最近的"节"（小寒、立春、惊蛰……大雪，即偶数序号节气）
+	monthCount := (year-1900)*12 - 1
+	for i := 0; i < 24; i += 2 {
+		if solarTermTime(year, i).Format("2006-01-02") > dateStr {
+			break
+		}
+		monthCount = (year-1900)*12 + i/2
+	}
+	return ((13+monthCount)%60 + 60) % 60
+}
+
+// 日干支，1900年1月31日为甲辰日
+func ganzhiDayIndex(date time.Time) int {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(date.Sub(lunarBase()).Hours() / 24)
+	return ((40+days)%60 + 60) % 60
+}
+
+// 获取当天的节气名称，非节气日返回空
+func solarTermOfDate(date time.Time) string {
+	dateStr := date.Format("2006-01-02")
+	// 每月两个节气，只需检查当月对应的两个
+	first := (int(date.Month()) - 1) * 2
+	for i := first; i < first+2; i++ {
+		if solarTermTime(date.Year(), i).Format("2006-01-02") == dateStr {
+			return SOLAR_TERM_NAMES[i]
+		}
+	}
+	return ""
+}
+
+// 获取当天的农历节日
+func lunarFestivals(lunar *lunarDate, solarTerm string) []string {
+	festivals := []string{}
+	if !lunar.IsLeap {
+		if name, ok := LUNAR_FESTIVALS[lunar.Month*100+lunar.Day]; ok {
+			festivals = append(festivals, name)
+		}
+		// 除夕为腊月最后一天
+		if lunar.Month == 12 && lunar.Day == lunar.MonthDays {
+			festivals = append(festivals, "除夕")
+		}
+	}
+	// 清明节、冬至以节气为准
+	if solarTerm == "清明" {
+		festivals = append(festivals, "清明节")
+	} else if solarTerm == "冬至" {
+		festivals = append(festivals, "冬至节")
+	}
+	return festivals
+}
+
+// 构建农历日期响应
+func buildLunarResponse(date time.Time, lunar *lunarDate) *model.LunarDateResponse {
+	yearIndex := ganzhiYearIndex(lunar.Year)
+	solarTerm := solarTermOfDate(date)
+	monthName := lunarMonthName(lunar.Month, lunar.IsLeap)
+	dayName := LUNAR_DAY_NAMES[lunar.Day-1]
+	yearGanzhi := ganzhiName(yearIndex)
+	zodiac := CHINESE_ZODIAC[yearIndex%12]
+
+	return &model.LunarDateResponse{
+		SolarDate:   date.Format("2006-01-02"),
+		Weekday:     int(date.Weekday()),
+		WeekdayName: utils.WeekdayNames[date.Weekday()],
+		LunarYear:   lunar.Year,
+		LunarMonth:  lunar.Month,
+		LunarDay:    lunar.Day,
+		IsLeapMonth: lunar.IsLeap,
+		LeapMonth:   lunar.LeapMonth,
+		MonthDays:   lunar.MonthDays,
+		MonthName:   monthName,
+		DayName:     dayName,
+		LunarText:   fmt.Sprintf("%s年%s%s", yearGanzhi, monthName, dayName),
+		GanzhiYear:  yearGanzhi,
+		GanzhiMonth: ganzhiName(ganzhiMonthIndex(date)),
+		GanzhiDay:   ganzhiName(ganzhiDayIndex(date)),
+		Zodiac:      zodiac,
+		SolarTerm:   solarTerm,
+		Festivals:   lunarFestivals(lunar, solarTerm),
+	}
+}
+
+// 公历日期转农历
+func ConvertSolarToLunar(req model.SolarToLunarRequest) (*model.LunarDateResponse, error) {
+	var date time.Time
+	if req.Date == "" {
+		now := time.Now().In(beijingZone)
+		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	} else {
+		if !utils.IsValidDateFormat(req.Date) {
+			return nil, &model.ErrorResponse{Code: 3007, Message: "日期格式错误，应为YYYY-MM-DD"}
+		}
+		d, err := utils.ParseDate(req.Date)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3007, Message: "日期解析失败: " + err.Error()}
+		}
+		date = d
+	}
+
+	lunar, err := solarToLunar(date)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3007, Message: err.Error()}
+	}
+
+	return buildLunarResponse(date, lunar), nil
+}
+
+// 农历日期转公历
+func ConvertLunarToSolar(req model.LunarToSolarRequest) (*model.LunarDateResponse, error) {
+	date, err := lunarToSolar(req.Year, req.Month, req.Day, req.IsLeapMonth)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3007, Message: err.Error()}
+	}
+
+	lunar, err := solarToLunar(date)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3007, Message: err.Error()}
+	}
+
+	return buildLunarResponse(date, lunar), nil
+}
+
+// 获取指定年份的二十四节气
+func GetSolarTerms(year int) (*model.SolarTermsResponse, error) {
+	if year == 0 {
+		year = time.Now().In(beijingZone).Year()
+	}
+	if year < LUNAR_MIN_YEAR || year > LUNAR_MAX_YEAR {
+		return nil, &model.ErrorResponse{Code: 3007, Message: fmt.Sprintf("年份超出支持范围(%d-%d)", LUNAR_MIN_YEAR, LUNAR_MAX_YEAR)}
+	}
+
+	terms := make([]model.SolarTermItem, 0, 24)
+	for i := 0; i < 24; i++ {
+		t := solarTermTime(year, i)
+		terms = append(terms, model.SolarTermItem{
+			Index: i + 1,
+			Name:  SOLAR_TERM_NAMES[i],
+			Date:  t.Format("2006-01-02"),
+			Time:  t.Format(time.RFC3339),
+		})
+	}
+
+	return &model.SolarTermsResponse{
+		Year:     year,
+		Timezone: "Asia/Shanghai",
+		Terms:    terms,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSolarToLunar(t *testing.T) {
+	cases := []struct {
+		solar            string
+		year, month, day int
+		isLeap           bool
+	}{
+		{"1900-01-31", 1900, 1, 1, false}, // 数据表起点
+		{"1901-02-19", 1901, 1, 1, false},
+		{"1949-01-29", 1949, 1, 1, false},
+		{"2000-02-05", 2000, 1, 1, false},
+		{"2017-07-23", 2017, 6, 1, true},
+		{"2020-05-23", 2020, 4, 1, true},
+		{"2023-03-22", 2023, 2, 1, true},
+		{"2023-04-20", 2023, 3, 1, false},
+		{"2024-02-10", 2024, 1, 1, false},
+		{"2033-12-22", 2033, 11, 1, true},
+		{"2099-01-21", 2099, 1, 1, false},
+		{"2100-02-09", 2100, 1, 1, false},
+		{"2101-01-28", 2100, 12, 29, false}, // 数据表终点
+	}
+	for _, c := range cases {
+		date, _ := time.Parse("2006-01-02", c.solar)
+		lunar, err := solarToLunar(date)
+		if err != nil {
+			t.Errorf("solarToLunar(%s) error: %v", c.solar, err)
+			continue
+		}
+		if lunar.Year != c.year || lunar.Month != c.month || lunar.Day != c.day || lunar.IsLeap != c.isLeap {
+			t.Errorf("solarToLunar(%s) = %d-%d-%d leap=%v, want %d-%d-%d leap=%v",
+				c.solar, lunar.Year, lunar.Month, lunar.Day, lunar.IsLeap, c.year, c.month, c.day, c.isLeap)
+		}
+
+		solar, err := lunarToSolar(c.year, c.month, c.day, c.isLeap)
+		if err != nil || solar.Format("2006-01-02") != c.solar {
+			t.Errorf("lunarToSolar(%d, %d, %d, %v) = %s (%v), want %s", c.year, c.month, c.day, c.isLeap, solar.Format("2006-01-02"), err, c.solar)
+		}
+	}
+}
+
+func TestSolarToLunarOutOfRange(t *testing.T) {
+	for _, s := range []string{"1900-01-30", "2101-01-29"} {
+		date, _ := time.Parse("2006-01-02", s)
+		if _, err := solarToLunar(date); err == nil {
+			t.Errorf("solarToLunar(%s) expected error", s)
+		}
+	}
+	if _, err := lunarToSolar(2024, 1, 1, true); err == nil {
+		t.Error("lunarToSolar(2024, 1, 1, leap) expected error: 2024 has no leap month")
+	}
+	if _, err := lunarToSolar(2023, 3, 1, true); err == nil {
+		t.Error("lunarToSolar(2023, 3, 1, leap) expected error: 2023 leap month is 2")
+	}
+}
+
+// 整个数据范围内逐日换算，农历日期应连续且能换算回原日期
+func TestLunarRoundTrip(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", lunarBaseDate)
+	end := time.Date(2101, 1, 28, 0, 0, 0, 0, time.UTC)
+	var previous *lunarDate
+	for ; !date.After(end); date = date.AddDate(0, 0, 1) {
+		lunar, err := solarToLunar(date)
+		if err != nil {
+			t.Fatalf("solarToLunar(%s) error: %v", date.Format("2006-01-02"), err)
+		}
+		if previous != nil && lunar.Day != previous.Day+1 && !(lunar.Day == 1 && previous.Day == previous.MonthDays) {
+			t.Fatalf("%s: lunar day %d does not follow %d", date.Format("2006-01-02"), lunar.Day, previous.Day)
+		}
+		solar, err := lunarToSolar(lunar.Year, lunar.Month, lunar.Day, lunar.IsLeap)
+		if err != nil || !solar.Equal(date) {
+			t.Fatalf("round trip %s -> %+v -> %s (%v)", date.Format("2006-01-02"), lunar, solar.Format("2006-01-02"), err)
+		}
+		previous = lunar
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"time"
+)
+
+const (
+	defaultMeetingSlotMinutes = 30
+	maxMeetingZones           = 20
+)
+
+// 参会时区：工作时间日历及显示名称
+type meetingZone struct {
+	name     string
+	calendar *businessCalendar
+	info     *model.TimezoneInfo
+}
+
+// 某时区在[start, end)内的工作时段，按时间升序
+func (z *meetingZone) workingIntervals(start, end time.Time) [][2]time.Time {
+	// 当地日期可能与规划日期相差一天，前后各多取一天
+	first := start.In(z.calendar.loc)
+	day := time.Date(first.Year(), first.Month(), first.Day()-1, 0, 0, 0, 0, z.calendar.loc)
+	intervals := [][2]time.Time{}
+	for !day.After(end) {
+		for _, period := range z.calendar.dayPeriods(day) {
+			if period[1].After(start) && period[0].Before(end) {
+				if period[0].Before(start) {
+					period[0] = start
+				}
+				if period[1].After(end) {
+					period[1] = end
+				}
+				intervals = append(intervals, period)
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, z.calendar.loc)
+	}
+	return intervals
+}
+
+// 判断时段是否完全处于工作时段内
+func containsInterval(intervals [][2]time.Time, start, end time.Time) bool {
+	for _, interval := range intervals {
+		if !start.Before(interval[0]) && !end.After(interval[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// 两组升序时段的交集
+func intersectIntervals(a, b [][2]time.Time) [][2]time.Time {
+	result := [][2]time.Time{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i][0], a[i][1]
+		if b[j][0].After(start) {
+			start = b[j][0]
+		}
+		if b[j][1].Before(end) {
+			end = b[j][1]
+		}
+		if start.Before(end) {
+			result = append(result, [2]time.Time{start, end})
+		}
+		if a[i][1].Before(b[j][1]) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// 各时区在指定时刻的本地时间
+func (z *meetingZone) localTime(start, end time.Time, isWorking bool) model.MeetingLocalTime {
+	localStart := start.In(z.calendar.loc)
+	result := model.MeetingLocalTime{
+		Name:      z.name,
+		Start:     localStart.Format("2006-01-02 15:04"),
+		Offset:    localStart.Format("-07:00"),
+		IsWorking: isWorking,
+	}
+	if !end.IsZero() {
+		result.End = end.In(z.calendar.loc).Format("2006-01-02 15:04")
+	}
+	return result
+}
+
+// 多时区会议规划：计算各时区工作时段的重叠部分，并按粒度列出每个时段在各时区的本地时间
+func PlanMeeting(req model.MeetingPlannerRequest) (*model.MeetingPlannerResponse, error) {
+	if len(req.Zones) > maxMeetingZones {
+		return nil, &model.ErrorResponse{Code: 3019, Message: fmt.Sprintf("时区数量不能超过%d", maxMeetingZones)}
+	}
+
+	slotMinutes := req.SlotMinutes
+	if slotMinutes == 0 {
+		slotMinutes = defaultMeetingSlotMinutes
+	}
+	if slotMinutes < 5 || 60%slotMinutes != 0 {
+		return nil, &model.ErrorResponse{Code: 3019, Message: "slot_minutes必须为5、10、15、20、30或60"}
+	}
+	minDuration := req.MinDuration
+	if minDuration == 0 {
+		minDuration = slotMinutes
+	}
+	if minDuration < 0 {
+		return nil, &model.ErrorResponse{Code: 3019, Message: "min_duration不能为负数"}
+	}
+
+	zones := make([]*meetingZone, 0, len(req.Zones))
+	for i, zone := range req.Zones {
+		calendar, timezoneInfo, err := newBusinessCalendar(zone.BusinessHoursOptions)
+		if err != nil {
+			if e, ok := err.(*model.ErrorResponse); ok {
+				return nil, &model.ErrorResponse{Code: e.Code, Message: fmt.Sprintf("第%d个时区%s", i+1, e.Message)}
+			}
+			return nil, err
+		}
+		name := zone.Name
+		if name == "" {
+			name = timezoneInfo.Name
+		}
+		zones = append(zones, &meetingZone{name: name, calendar: calendar, info: timezoneInfo})
+	}
+
+	// 规划日期以第一个时区为准，夏令时切换日可能为23或25小时
+	baseLoc := zones[0].calendar.loc
+	var dayStart time.Time
+	if req.Date == "" {
+		now := time.Now().In(baseLoc)
+		dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, baseLoc)
+	} else {
+		if !utils.IsValidDateFormat(req.Date) {
+			return nil, &model.ErrorResponse{Code: 3019, Message: "日期格式错误，应为YYYY-MM-DD"}
+		}
+		d, err := time.ParseInLocation("2006-01-02", req.Date, baseLoc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3019, Message: "日期解析失败: " + err.Error()}
+		}
+		dayStart = d
+	}
+	dayEnd := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day()+1, 0, 0, 0, 0, baseLoc)
+
+	// 各时区的工作时段及其交集
+	zoneInfos := make([]model.MeetingZoneInfo, 0, len(zones))
+	zoneIntervals := make([][][2]time.Time, 0, len(zones))
+	var overlap [][2]time.Time
+	for i, zone := range zones {
+		intervals := zone.workingIntervals(dayStart, dayEnd)
+		zoneIntervals = append(zoneIntervals, intervals)
+		if i == 0 {
+			overlap = intervals
+		} else {
+			overlap = intersectIntervals(overlap, intervals)
+		}
+
+		localStart := dayStart.In(zone.calendar.loc)
+		zoneInfos = append(zoneInfos, model.MeetingZoneInfo{
+			Name:         zone.name,
+			Timezone:     zone.info.Name,
+			Offset:       localStart.Format("-07:00"),
+			IsDST:        localStart.IsDST(),
+			WorkingHours: formatWorkingPeriods(zone.calendar.periods),
+		})
+	}
+
+	windows := []model.MeetingWindow{}
+	for _, interval := range overlap {
+		duration := int(interval[1].Sub(interval[0]) / time.Minute)
+		if duration < minDuration {
+			continue
+		}
+		window := model.MeetingWindow{
+			Start:           interval[0].In(baseLoc).Format(time.RFC3339),
+			End:             interval[1].In(baseLoc).Format(time.RFC3339),
+			StartTimestamp:  interval[0].Unix(),
+			EndTimestamp:    interval[1].Unix(),
+			DurationMinutes: duration,
+			Local:           make([]model.MeetingLocalTime, 0, len(zones)),
+		}
+		for _, zone := range zones {
+			window.Local = append(window.Local, zone.localTime(interval[0], interval[1], true))
+		}
+		windows = append(windows, window)
+	}
+
+	// 时间表按实际经过时间划分，夏令时切换时各时区的本地时间随之变化
+	slotDuration := time.Duration(slotMinutes) * time.Minute
+	slots := []model.MeetingSlot{}
+	for start := dayStart; start.Before(dayEnd); start = start.Add(slotDuration) {
+		end := start.Add(slotDuration)
+		slot := model.MeetingSlot{
+			Start:     start.Format(time.RFC3339),
+			Timestamp: start.Unix(),
+			Local:     make([]model.MeetingLocalTime, 0, len(zones)),
+		}
+		for i, zone := range zones {
+			isWorking := containsInterval(zoneIntervals[i], start, end)
+			if isWorking {
+				slot.AvailableCount++
+			}
+			slot.Local = append(slot.Local, zone.localTime(start, time.Time{}, isWorking))
+		}
+		slot.AllAvailable = slot.AvailableCount == len(zones)
+		slots = append(slots, slot)
+	}
+
+	return &model.MeetingPlannerResponse{
+		Date:        dayStart.Format("2006-01-02"),
+		Timezone:    zones[0].info.Name,
+		SlotMinutes: slotMinutes,
+		Zones:       zoneInfos,
+		Windows:     windows,
+		Slots:       slots,
+	}, nil
+}
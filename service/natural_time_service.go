@@ -0,0 +1,763 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 数字片段，支持阿拉伯数字及中文数字（如 3、十五、两）
+const naturalNumber = `([0-9]+|[零〇一二两三四五六七八九十百千]+)`
+
+// 英文数字片段
+const naturalNumberEn = `([0-9]+|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)`
+
+// 时段词，如 下午、晚上
+const naturalPeriod = `(早上|早晨|上午|中午|午后|下午|傍晚|晚上|今晚|夜里|半夜|凌晨)`
+
+// 英文月份片段
+const naturalMonthEn = `(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept|sep|oct|nov|dec)`
+
+// 英文数字值
+var naturalNumbersEn = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// 相对日期词对应的天数偏移
+var naturalDayOffsets = map[string]int{
+	"大前天": -3, "前天": -2, "昨天": -1, "昨日": -1, "今天": 0, "今日": 0,
+	"明天": 1, "明日": 1, "后天": 2, "大后天": 3,
+	"today": 0, "tomorrow": 1, "yesterday": -1,
+	"the day after tomorrow": 2, "day after tomorrow": 2,
+	"the day before yesterday": -2, "day before yesterday": -2,
+}
+
+// 前缀对应的周、月、年偏移
+var naturalPrefixOffsets = map[string]int{
+	"上上": -2, "上": -1, "这": 0, "本": 0, "下": 1, "下下": 2,
+	"今年": 0, "明年": 1, "去年": -1, "后年": 2, "前年": -2,
+	"last": -1, "this": 0, "next": 1,
+}
+
+// 时段的默认时刻
+var naturalPeriodHours = map[string]int{
+	"早上": 8, "早晨": 8, "上午": 9, "中午": 12, "午后": 14, "下午": 15, "傍晚": 18,
+	"晚上": 20, "今晚": 20, "夜里": 22, "半夜": 23, "凌晨": 0,
+	"morning": 9, "afternoon": 15, "evening": 19, "night": 21, "tonight": 20,
+}
+
+// 节日日期，lunar为农历日期
+var naturalFestivals = map[string]struct {
+	month, day int
+	lunar      bool
+}{
+	"春节": {1, 1, true}, "元宵": {1, 15, true}, "端午": {5, 5, true}, "七夕": {7, 7, true},
+	"中秋": {8, 15, true}, "重阳": {9, 9, true}, "腊八": {12, 8, true},
+	"元旦": {1, 1, false}, "情人": {2, 14, false}, "劳动": {5, 1, false}, "五一": {5, 1, false},
+	"儿童": {6, 1, false}, "国庆": {10, 1, false}, "圣诞": {12, 25, false},
+	"christmas": {12, 25, false}, "new year": {1, 1, false}, "valentine": {2, 14, false},
+}
+
+// 自然语言时间识别结果
+type naturalResult struct {
+	time       time.Time
+	hasTime    bool
+	confidence float64
+}
+
+// 日期规则，返回ok=false表示虽然匹配但无法换算（如日期无效）
+type naturalDateRule struct {
+	pattern *regexp.Regexp
+	resolve func(m []string, ref time.Time) (naturalResult, bool)
+}
+
+// 时刻规则，返回时、分、秒
+type naturalClockRule struct {
+	pattern *regexp.Regexp
+	resolve func(m []string) (int, int, int, float64, bool)
+}
+
+// 解析中文或阿拉伯数字，中文数字按parseChineseInteger解析（如 十五、二十三、三百五即350）
+func parseNaturalNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	if n, ok := naturalNumbersEn[strings.ToLower(s)]; ok {
+		return n, true
+	}
+
+	n, err := parseChineseInteger(s)
+	if err != nil {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// 当天零点
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// 所在周的周一零点
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// 日期结果（当天零点，不含时刻）
+func naturalDate(year int, month time.Month, day int, loc *time.Location, confidence float64) (naturalResult, bool) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if t.Day() != day || t.Month() != month {
+		return naturalResult{}, false
+	}
+	return naturalResult{time: t, confidence: confidence}, true
+}
+
+// 星期名称转换为time.Weekday
+func naturalWeekday(s string) (time.Weekday, bool) {
+	switch strings.ToLower(s) {
+	case "一", "1", "monday", "mon":
+		return time.Monday, true
+	case "二", "2", "tuesday", "tue", "tues":
+		return time.Tuesday, true
+	case "三", "3", "wednesday", "wed":
+		return time.Wednesday, true
+	case "四", "4", "thursday", "thu", "thur", "thurs":
+		return time.Thursday, true
+	case "五", "5", "friday", "fri":
+		return time.Friday, true
+	case "六", "6", "saturday", "sat":
+		return time.Saturday, true
+	case "日", "天", "7", "sunday", "sun":
+		return time.Sunday, true
+	}
+	return 0, false
+}
+
+// 英文月份名称转换为time.Month
+func naturalMonthEnValue(s string) time.Month {
+	s = strings.ToLower(s)
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), s[:3]) {
+			return m
+		}
+	}
+	return 0
+}
+
+// 指定星期：有前缀时取对应周（周一为每周第一天）的该星期，否则取今天及以后最近的一天
+func resolveNaturalWeekday(prefix string, weekday time.Weekday, ref time.Time) naturalResult {
+	if prefix == "" || prefix == "coming" {
+		days := (int(weekday) - int(ref.Weekday()) + 7) % 7
+		return naturalResult{time: startOfDay(ref).AddDate(0, 0, days), confidence: 0.85}
+	}
+	confidence := 0.95
+	if prefix == "next" || prefix == "last" {
+		// 英文next/last存在"下周"与"最近的"两种理解
+		confidence = 0.8
+	}
+	offset := naturalPrefixOffsets[prefix]*7 + (int(weekday)+6)%7
+	return naturalResult{time: startOfWeek(ref).AddDate(0, 0, offset), confidence: confidence}
+}
+
+// 月内位置：底为最后一天，初为1日，中为15日
+func resolveMonthPart(year int, month time.Month, part string, loc *time.Location) naturalResult {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	switch part {
+	case "底", "末", "end":
+		return naturalResult{time: first.AddDate(0, 1, -1), confidence: 0.9}
+	case "中", "middle":
+		return naturalResult{time: first.AddDate(0, 0, 14), confidence: 0.75}
+	default:
+		return naturalResult{time: first, confidence: 0.8}
+	}
+}
+
+// 节日日期，year为0时取参考日期当天及以后最近的一次
+func resolveNaturalFestival(name string, year int, ref time.Time) (naturalResult, bool) {
+	if _, ok := naturalFestivals[name]; !ok {
+		name = strings.TrimSuffix(name, "节")
+	}
+
+	dateOf := func(y int) (time.Time, bool) {
+		switch name {
+		case "除夕":
+			// 春节前一天
+			t, err := lunarToSolar(y, 1, 1, false)
+			if err != nil {
+				return time.Time{}, false
+			}
+			t = t.AddDate(0, 0, -1)
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, ref.Location()), true
+		case "清明":
+			t := solarTermTime(y, 6)
+			return time.Date(y, t.Month(), t.Day(), 0, 0, 0, 0, ref.Location()), true
+		}
+		festival, ok := naturalFestivals[name]
+		if !ok {
+			return time.Time{}, false
+		}
+		if !festival.lunar {
+			return time.Date(y, time.Month(festival.month), festival.day, 0, 0, 0, 0, ref.Location()), true
+		}
+		t, err := lunarToSolar(y, festival.month, festival.day, false)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, ref.Location()), true
+	}
+
+	if year != 0 {
+		t, ok := dateOf(year)
+		return naturalResult{time: t, confidence: 0.95}, ok
+	}
+	t, ok := dateOf(ref.Year())
+	if ok && t.Before(startOfDay(ref)) {
+		t, ok = dateOf(ref.Year() + 1)
+	}
+	return naturalResult{time: t, confidence: 0.9}, ok
+}
+
+// 时长偏移，如 3天后、1个半小时前、in 2 weeks
+func resolveNaturalDuration(amount float64, unit string, sign int, ref time.Time) naturalResult {
+	whole := int(amount)
+	half := amount != float64(whole)
+
+	var duration time.Duration
+	years, months, days := 0, 0, 0
+	switch unit {
+	case "秒", "秒钟", "second":
+		duration = time.Duration(amount * float64(time.Second))
+	case "分", "分钟", "minute":
+		duration = time.Duration(amount * float64(time.Minute))
+	case "小时", "钟头", "hour":
+		duration = time.Duration(amount * float64(time.Hour))
+	case "天", "日", "day":
+		days = whole
+		if half {
+			duration = 12 * time.Hour
+		}
+	case "周", "星期", "礼拜", "week":
+		days = whole * 7
+		if half {
+			duration = 84 * time.Hour
+		}
+	case "月", "month":
+		months = whole
+		if half {
+			days = 15
+		}
+	case "年", "year":
+		years = whole
+		if half {
+			months = 6
+		}
+	}
+
+	// 以天及以上为单位时返回日期，否则返回具体时刻
+	if duration == 0 {
+		return naturalResult{time: startOfDay(ref).AddDate(sign*years, sign*months, sign*days), confidence: 0.95}
+	}
+	t := ref.AddDate(sign*years, sign*months, sign*days).Add(time.Duration(sign) * duration)
+	return naturalResult{time: t, hasTime: true, confidence: 0.95}
+}
+
+// 自然语言日期规则
+var naturalDateRules = []naturalDateRule{
+	// 现在
+	{regexp.MustCompile(`(?i)^(现在|此刻|right\s+now|now)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		return naturalResult{time: ref, hasTime: true, confidence: 1}, true
+	}},
+	// 今天、明天、后天
+	{regexp.MustCompile(`(?i)^(大前天|前天|昨天|昨日|今天|今日|明天|明日|大后天|后天|the\s+day\s+after\s+tomorrow|the\s+day\s+before\s+yesterday|day\s+after\s+tomorrow|day\s+before\s+yesterday|today|tomorrow|yesterday)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		key := strings.Join(strings.Fields(strings.ToLower(m[1])), " ")
+		return naturalResult{time: startOfDay(ref).AddDate(0, 0, naturalDayOffsets[key]), confidence: 1}, true
+	}},
+	// 3天后、两周前、1个半小时后
+	{regexp.MustCompile(`^(` + naturalNumber[1:len(naturalNumber)-1] + `|半)(个)?(半)?(秒钟|秒|分钟|分|小时|钟头|天|日|周|星期|礼拜|月|年)(以后|之后|后|以前|之前|前)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		amount := 0.5
+		if m[1] != "半" {
+			n, ok := parseNaturalNumber(m[1])
+			if !ok {
+				return naturalResult{}, false
+			}
+			amount = float64(n)
+			if m[3] != "" {
+				amount += 0.5
+			}
+		}
+		// "3月后"易与月份混淆，需写作"3个月后"
+		if m[4] == "月" && m[2] == "" && m[1] != "半" {
+			return naturalResult{}, false
+		}
+		sign := 1
+		if strings.HasSuffix(m[5], "前") {
+			sign = -1
+		}
+		return resolveNaturalDuration(amount, m[4], sign, ref), true
+	}},
+	// in 3 days、in half an hour
+	{regexp.MustCompile(`(?i)^in\s+(half\s+an?|` + naturalNumberEn[1:len(naturalNumberEn)-1] + `)\s+(second|minute|hour|day|week|month|year)s?`), func(m []string, ref time.Time) (naturalResult, bool) {
+		amount := 0.5
+		if !strings.HasPrefix(strings.ToLower(m[1]), "half") {
+			n, _ := parseNaturalNumber(m[1])
+			amount = float64(n)
+		}
+		return resolveNaturalDuration(amount, strings.ToLower(m[2]), 1, ref), true
+	}},
+	// 3 days ago、2 weeks later
+	{regexp.MustCompile(`(?i)^` + naturalNumberEn + `\s+(second|minute|hour|day|week|month|year)s?\s+(ago|later|from\s+now|after)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		n, _ := parseNaturalNumber(m[1])
+		sign := 1
+		if strings.EqualFold(m[3], "ago") {
+			sign = -1
+		}
+		return resolveNaturalDuration(float64(n), strings.ToLower(m[2]), sign, ref), true
+	}},
+	// 2026-10-17、2026/10/17
+	{regexp.MustCompile(`^([0-9]{4})[-/.]([0-9]{1,2})[-/.]([0-9]{1,2})`), func(m []string, ref time.Time) (naturalResult, bool) {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return naturalDate(year, time.Month(month), day, ref.Location(), 1)
+	}},
+	// 2026年10月17日、明年3月5号、10月底
+	{regexp.MustCompile(`^(?:([0-9]{4})年|(今年|明年|去年|后年|前年))?` + naturalNumber + `月(?:` + naturalNumber + `[日号]?|(底|末|初|中))`), func(m []string, ref time.Time) (naturalResult, bool) {
+		year := ref.Year()
+		confidence := 0.9
+		if m[1] != "" {
+			year, _ = strconv.Atoi(m[1])
+			confidence = 1
+		} else if m[2] != "" {
+			year += naturalPrefixOffsets[m[2]]
+			confidence = 0.95
+		}
+		month, ok := parseNaturalNumber(m[3])
+		if !ok || month < 1 || month > 12 {
+			return naturalResult{}, false
+		}
+		if m[5] != "" {
+			result := resolveMonthPart(year, time.Month(month), m[5], ref.Location())
+			return result, true
+		}
+		day, ok := parseNaturalNumber(m[4])
+		if !ok {
+			return naturalResult{}, false
+		}
+		return naturalDate(year, time.Month(month), day, ref.Location(), confidence)
+	}},
+	// 下周三、周五、这个星期天
+	{regexp.MustCompile(`^(上上|下下|上|下|这|本)?(?:个)?(?:周|星期|礼拜)([一二三四五六日天1-7])`), func(m []string, ref time.Time) (naturalResult, bool) {
+		weekday, _ := naturalWeekday(m[2])
+		return resolveNaturalWeekday(m[1], weekday, ref), true
+	}},
+	// 下周末、周末
+	{regexp.MustCompile(`^(上上|下下|上|下|这|本)?(?:个)?(?:周|星期|礼拜)末`), func(m []string, ref time.Time) (naturalResult, bool) {
+		result := resolveNaturalWeekday(m[1], time.Saturday, ref)
+		result.confidence = 0.85
+		return result, true
+	}},
+	// 下周（取周一）
+	{regexp.MustCompile(`^(上上|下下|上|下|这|本)(?:个)?(?:周|星期|礼拜)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		result := resolveNaturalWeekday(m[1], time.Monday, ref)
+		result.confidence = 0.7
+		return result, true
+	}},
+	// next friday、monday
+	{regexp.MustCompile(`(?i)^(?:(next|last|this|coming)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tues|tue|wed|thurs|thur|thu|fri|sat|sun)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		weekday, _ := naturalWeekday(m[2])
+		return resolveNaturalWeekday(strings.ToLower(m[1]), weekday, ref), true
+	}},
+	// 月底、下个月初、下个月5号
+	{regexp.MustCompile(`^(上上|下下|上|下|这|本)?(?:个)?月(?:(底|末|初|中)|` + naturalNumber + `[日号])`), func(m []string, ref time.Time) (naturalResult, bool) {
+		first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location()).AddDate(0, naturalPrefixOffsets[m[1]], 0)
+		if m[2] != "" {
+			return resolveMonthPart(first.Year(), first.Month(), m[2], ref.Location()), true
+		}
+		day, ok := parseNaturalNumber(m[3])
+		if !ok {
+			return naturalResult{}, false
+		}
+		return naturalDate(first.Year(), first.Month(), day, ref.Location(), 0.95)
+	}},
+	// 下个月（取1日）
+	{regexp.MustCompile(`^(上上|下下|上|下|这|本)(?:个)?月`), func(m []string, ref time.Time) (naturalResult, bool) {
+		first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location()).AddDate(0, naturalPrefixOffsets[m[1]], 0)
+		return naturalResult{time: first, confidence: 0.7}, true
+	}},
+	// next week、this month、next weekend
+	{regexp.MustCompile(`(?i)^(next|last|this)\s+(weekend|week|month|year)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		offset := naturalPrefixOffsets[strings.ToLower(m[1])]
+		switch strings.ToLower(m[2]) {
+		case "weekend":
+			result := resolveNaturalWeekday(strings.ToLower(m[1]), time.Saturday, ref)
+			result.confidence = 0.8
+			return result, true
+		case "week":
+			return naturalResult{time: startOfWeek(ref).AddDate(0, 0, offset*7), confidence: 0.7}, true
+		case "month":
+			first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location())
+			return naturalResult{time: first.AddDate(0, offset, 0), confidence: 0.7}, true
+		default:
+			return naturalResult{time: time.Date(ref.Year()+offset, 1, 1, 0, 0, 0, 0, ref.Location()), confidence: 0.6}, true
+		}
+	}},
+	// end of the month、beginning of next month
+	{regexp.MustCompile(`(?i)^(?:the\s+)?(end|beginning|start|middle)\s+of\s+(?:the\s+)?(?:(next|last|this)\s+)?(month|year)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		part := strings.ToLower(m[1])
+		offset := naturalPrefixOffsets[strings.ToLower(m[2])]
+		if strings.EqualFold(m[3], "year") {
+			year := ref.Year() + offset
+			switch part {
+			case "end":
+				return naturalResult{time: time.Date(year, 12, 31, 0, 0, 0, 0, ref.Location()), confidence: 0.9}, true
+			case "middle":
+				return naturalResult{time: time.Date(year, 7, 1, 0, 0, 0, 0, ref.Location()), confidence: 0.7}, true
+			default:
+				return naturalResult{time: time.Date(year, 1, 1, 0, 0, 0, 0, ref.Location()), confidence: 0.8}, true
+			}
+		}
+		first := time.Date(ref.Year(), ref.Month(), 1, 0, 0, 0, 0, ref.Location()).AddDate(0, offset, 0)
+		return resolveMonthPart(first.Year(), first.Month(), part, ref.Location()), true
+	}},
+	// 年底、明年年初、今年底
+	{regexp.MustCompile(`^(?:(今年|明年|去年|后年|前年)年?|年)(底|末|初|中)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		year := ref.Year() + naturalPrefixOffsets[m[1]]
+		switch m[2] {
+		case "底", "末":
+			return naturalResult{time: time.Date(year, 12, 31, 0, 0, 0, 0, ref.Location()), confidence: 0.9}, true
+		case "中":
+			return naturalResult{time: time.Date(year, 7, 1, 0, 0, 0, 0, ref.Location()), confidence: 0.7}, true
+		default:
+			return naturalResult{time: time.Date(year, 1, 1, 0, 0, 0, 0, ref.Location()), confidence: 0.8}, true
+		}
+	}},
+	// 明年春节、中秋节、除夕
+	{regexp.MustCompile(`^(?:([0-9]{4})年|(今年|明年|去年|后年|前年))?(春节|元宵节?|端午节?|七夕节?|中秋节?|重阳节?|腊八节?|除夕|清明节?|元旦|情人节|劳动节|五一|儿童节|国庆节?|圣诞节?)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		year := 0
+		if m[1] != "" {
+			year, _ = strconv.Atoi(m[1])
+		} else if m[2] != "" {
+			year = ref.Year() + naturalPrefixOffsets[m[2]]
+		}
+		return resolveNaturalFestival(m[3], year, ref)
+	}},
+	// christmas、new year's day
+	{regexp.MustCompile(`(?i)^(christmas|new\s+year'?s\s+day|new\s+year|valentine'?s\s+day)`), func(m []string, ref time.Time) (naturalResult, bool) {
+		name := "christmas"
+		switch strings.ToLower(m[1][:1]) {
+		case "n":
+			name = "new year"
+		case "v":
+			name = "valentine"
+		}
+		return resolveNaturalFestival(name, 0, ref)
+	}},
+	// October 17, 2026、Oct 17
+	{regexp.MustCompile(`(?i)^` + naturalMonthEn + `\.?\s+([0-9]{1,2})(?:st|nd|rd|th)?(?:,?\s+([0-9]{4}))?`), func(m []string, ref time.Time) (naturalResult, bool) {
+		day, _ := strconv.Atoi(m[2])
+		year, confidence := ref.Year(), 0.9
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			confidence = 1
+		}
+		return naturalDate(year, naturalMonthEnValue(m[1]), day, ref.Location(), confidence)
+	}},
+	// 17 October 2026、17th of Oct
+	{regexp.MustCompile(`(?i)^([0-9]{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?` + naturalMonthEn + `\.?(?:,?\s+([0-9]{4}))?`), func(m []string, ref time.Time) (naturalResult, bool) {
+		day, _ := strconv.Atoi(m[1])
+		year, confidence := ref.Year(), 0.9
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			confidence = 1
+		}
+		return naturalDate(year, naturalMonthEnValue(m[2]), day, ref.Location(), confidence)
+	}},
+	// 15号（本月）
+	{regexp.MustCompile(`^` + naturalNumber + `[日号]`), func(m []string, ref time.Time) (naturalResult, bool) {
+		day, ok := parseNaturalNumber(m[1])
+		if !ok {
+			return naturalResult{}, false
+		}
+		return naturalDate(ref.Year(), ref.Month(), day, ref.Location(), 0.8)
+	}},
+}
+
+// 按时段调整小时，如 下午3点为15点、晚上12点为次日0点
+func adjustNaturalHour(period string, hour int) int {
+	switch period {
+	case "下午", "午后", "傍晚", "晚上", "今晚", "夜里", "pm", "p.m.":
+		if hour < 12 {
+			return hour + 12
+		}
+		if period == "晚上" || period == "夜里" {
+			return 24
+		}
+	case "中午":
+		if hour < 6 {
+			return hour + 12
+		}
+	case "凌晨", "半夜", "am", "a.m.":
+		if hour == 12 {
+			return 0
+		}
+	}
+	return hour
+}
+
+// 自然语言时刻规则
+var naturalClockRules = []naturalClockRule{
+	// 下午3:30、15:30:00
+	{regexp.MustCompile(`^` + naturalPeriod + `?\s*([0-9]{1,2})[:：]([0-9]{2})(?:[:：]([0-9]{2}))?`), func(m []string) (int, int, int, float64, bool) {
+		hour, _ := strconv.Atoi(m[2])
+		minute, _ := strconv.Atoi(m[3])
+		second, _ := strconv.Atoi(m[4])
+		if hour > 23 || minute > 59 || second > 59 {
+			return 0, 0, 0, 0, false
+		}
+		return adjustNaturalHour(m[1], hour), minute, second, 1, true
+	}},
+	// 下午3点、3点半、八点一刻、10点15分
+	{regexp.MustCompile(`^` + naturalPeriod + `?` + naturalNumber + `(?:点钟|点|时)(?:(半|一刻|三刻)|` + naturalNumber + `分?)?`), func(m []string) (int, int, int, float64, bool) {
+		hour, ok := parseNaturalNumber(m[2])
+		if !ok || hour > 24 {
+			return 0, 0, 0, 0, false
+		}
+		minute := 0
+		switch m[3] {
+		case "半":
+			minute = 30
+		case "一刻":
+			minute = 15
+		case "三刻":
+			minute = 45
+		}
+		if m[4] != "" {
+			minute, ok = parseNaturalNumber(m[4])
+			if !ok || minute > 59 {
+				return 0, 0, 0, 0, false
+			}
+		}
+		// 未指明上午下午时，12点以内的小时存在歧义
+		confidence := 1.0
+		if m[1] == "" && hour <= 12 {
+			confidence = 0.8
+		}
+		return adjustNaturalHour(m[1], hour), minute, 0, confidence, true
+	}},
+	// 下午、晚上（取默认时刻）
+	{regexp.MustCompile(`^` + naturalPeriod), func(m []string) (int, int, int, float64, bool) {
+		return naturalPeriodHours[m[1]], 0, 0, 0.7, true
+	}},
+	// 3pm、at 10:30 am
+	{regexp.MustCompile(`(?i)^(?:at\s+)?([0-9]{1,2})(?::([0-9]{2}))?\s*(a\.m\.|p\.m\.|am|pm)`), func(m []string) (int, int, int, float64, bool) {
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		if hour < 1 || hour > 12 || minute > 59 {
+			return 0, 0, 0, 0, false
+		}
+		return adjustNaturalHour(strings.ToLower(m[3]), hour), minute, 0, 1, true
+	}},
+	// at noon、midnight
+	{regexp.MustCompile(`(?i)^(?:at\s+)?(noon|midnight)`), func(m []string) (int, int, int, float64, bool) {
+		if strings.EqualFold(m[1], "noon") {
+			return 12, 0, 0, 1, true
+		}
+		return 0, 0, 0, 0.9, true
+	}},
+	// at 9（未指明上午下午）
+	{regexp.MustCompile(`(?i)^at\s+([0-9]{1,2})(?:\s*o'clock)?`), func(m []string) (int, int, int, float64, bool) {
+		hour, _ := strconv.Atoi(m[1])
+		if hour > 23 {
+			return 0, 0, 0, 0, false
+		}
+		return hour, 0, 0, 0.8, true
+	}},
+	// in the morning、tonight
+	{regexp.MustCompile(`(?i)^(?:in\s+the\s+|this\s+)?(morning|afternoon|evening|night|tonight)`), func(m []string) (int, int, int, float64, bool) {
+		return naturalPeriodHours[strings.ToLower(m[1])], 0, 0, 0.7, true
+	}},
+}
+
+// 是否为英文字母或数字，用于判断单词边界
+func isNaturalWordChar(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// 匹配结束位置是否处于单词边界，避免 monday 匹配 mondays、3点 匹配 13点 的一部分
+func naturalBoundaryAt(text string, start, end int) bool {
+	if start > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(text[:start])
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		if isNaturalWordChar(prev) && isNaturalWordChar(first) {
+			return false
+		}
+	}
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if isNaturalWordChar(last) && isNaturalWordChar(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// 在pos处匹配最长的日期表达式
+func matchNaturalDate(text string, pos int, ref time.Time) (naturalResult, int, bool) {
+	var best naturalResult
+	bestEnd := -1
+	for _, rule := range naturalDateRules {
+		m := rule.pattern.FindStringSubmatch(text[pos:])
+		if m == nil || pos+len(m[0]) <= bestEnd || !naturalBoundaryAt(text, pos, pos+len(m[0])) {
+			continue
+		}
+		if result, ok := rule.resolve(m, ref); ok {
+			best, bestEnd = result, pos+len(m[0])
+		}
+	}
+	return best, bestEnd, bestEnd > pos
+}
+
+// 在pos处匹配最长的时刻表达式
+func matchNaturalClock(text string, pos int) (int, int, int, float64, int, bool) {
+	var hour, minute, second int
+	var confidence float64
+	bestEnd := -1
+	for _, rule := range naturalClockRules {
+		m := rule.pattern.FindStringSubmatch(text[pos:])
+		if m == nil || pos+len(m[0]) <= bestEnd || !naturalBoundaryAt(text, pos, pos+len(m[0])) {
+			continue
+		}
+		if h, mi, s, c, ok := rule.resolve(m); ok {
+			hour, minute, second, confidence, bestEnd = h, mi, s, c, pos+len(m[0])
+		}
+	}
+	return hour, minute, second, confidence, bestEnd, bestEnd > pos
+}
+
+// 跳过日期与时刻之间的分隔符，如空格、逗号、"的"
+func skipNaturalSeparators(text string, pos int) int {
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if !unicode.IsSpace(r) && r != ',' && r != '，' && r != '的' {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
+
+// 设置日期的时刻
+func withNaturalClock(day time.Time, hour, minute, second int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location())
+}
+
+// 在文本中查找第一个时间表达式，返回结果及匹配的字节范围
+func findNaturalTime(text string, ref time.Time) (naturalResult, int, int, bool) {
+	for pos := 0; pos < len(text); {
+		if result, end, ok := matchNaturalDate(text, pos, ref); ok {
+			// 日期后接时刻，如 明天下午3点、tomorrow at 9am
+			if !result.hasTime {
+				if hour, minute, second, confidence, clockEnd, ok := matchNaturalClock(text, skipNaturalSeparators(text, end)); ok {
+					result.time = withNaturalClock(result.time, hour, minute, second)
+					result.hasTime = true
+					result.confidence = math.Min(result.confidence, confidence)
+					end = clockEnd
+				}
+			}
+			return result, pos, end, true
+		}
+
+		if hour, minute, second, confidence, end, ok := matchNaturalClock(text, pos); ok {
+			// 时刻后接日期，如 3pm tomorrow，否则为参考日期当天
+			day := naturalResult{time: startOfDay(ref), confidence: 1}
+			if date, dateEnd, ok := matchNaturalDate(text, skipNaturalSeparators(text, end), ref); ok && !date.hasTime {
+				day, end = date, dateEnd
+			}
+			return naturalResult{
+				time:       withNaturalClock(day.time, hour, minute, second),
+				hasTime:    true,
+				confidence: math.Min(day.confidence, confidence),
+			}, pos, end, true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return naturalResult{}, 0, 0, false
+}
+
+// 解析中英文自然语言时间表达式，如"下周三下午3点"、"3天后"、"明年春节"、"next friday at 3pm"
+func ParseNaturalTime(req model.NaturalTimeRequest) (*model.NaturalTimeResponse, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, &model.ErrorResponse{Code: 3012, Message: "text参数不能为空"}
+	}
+
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3012, Message: err.Error()}
+	}
+
+	ref := time.Now().In(loc)
+	if req.ReferenceTime != nil {
+		t, _, err := parseTimeInput(req.ReferenceTime, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3012, Message: "参考时间" + err.Error()}
+		}
+		ref = t.In(loc)
+	}
+
+	// 整段文本为标准时间格式时直接解析
+	var result naturalResult
+	var start, end int
+	if t, err := utils.ParseDateTimeInLocation(text, loc); err == nil {
+		result = naturalResult{time: t.In(loc), hasTime: strings.ContainsAny(text, ":"), confidence: 1}
+		start, end = 0, len(text)
+	} else {
+		var ok bool
+		result, start, end, ok = findNaturalTime(text, ref)
+		if !ok {
+			return nil, &model.ErrorResponse{Code: 3012, Message: fmt.Sprintf("未能识别时间表达式: %s", text)}
+		}
+	}
+
+	// 文本中未识别的部分越多，置信度越低
+	matched := text[start:end]
+	coverage := float64(utf8.RuneCountInString(matched)) / float64(utf8.RuneCountInString(text))
+	confidence := math.Round(result.confidence*(0.6+0.4*coverage)*100) / 100
+
+	if req.OutputFormat == "" {
+		req.OutputFormat = "default"
+	}
+	if err := checkOutputFormat(req.OutputFormat); err != nil {
+		return nil, &model.ErrorResponse{Code: 3012, Message: err.Error()}
+	}
+
+	return &model.NaturalTimeResponse{
+		Text:          text,
+		Matched:       matched,
+		Start:         utf8.RuneCountInString(text[:start]),
+		End:           utf8.RuneCountInString(text[:end]),
+		Converted:     formatTime(result.time, req.OutputFormat, req.CustomFormat),
+		Date:          result.time.Format("2006-01-02"),
+		Timestamp:     result.time.Unix(),
+		HasTime:       result.hasTime,
+		Confidence:    confidence,
+		ReferenceTime: ref.Format("2006-01-02 15:04:05"),
+		Timezone:      timezoneInfo.Name,
+		TimezoneInfo:  *timezoneInfo,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import "testing"
+
+func TestParseNaturalNumber(t *testing.T) {
+	cases := []struct {
+		text string
+		want int
+	}{
+		{"15", 15},
+		{"twelve", 12},
+		{"两", 2},
+		{"十五", 15},
+		{"二十三", 23},
+		{"一百", 100},
+		{"三百五", 350},
+		{"三百零五", 305},
+		{"一千二", 1200},
+		{"两千零二十四", 2024},
+		{"二〇二四", 2024},
+	}
+	for _, c := range cases {
+		if got, ok := parseNaturalNumber(c.text); !ok || got != c.want {
+			t.Errorf("parseNaturalNumber(%s) = %d, %v, want %d", c.text, got, ok, c.want)
+		}
+	}
+	if _, ok := parseNaturalNumber("三x"); ok {
+		t.Error("parseNaturalNumber(三x) expected failure")
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"time"
+)
+
+// 按月划分的周期类型及其月数
+var PERIOD_MONTHS = map[string]int{
+	"month":       1,
+	"quarter":     3,
+	"half_year":   6,
+	"year":        12,
+	"fiscal_year": 12,
+}
+
+// 周期类型的中文名称
+var PERIOD_NAMES = map[string]string{
+	"week":        "ISO周",
+	"month":       "月份",
+	"quarter":     "季度",
+	"half_year":   "半年",
+	"year":        "年",
+	"fiscal_year": "财年",
+}
+
+// ISO年份的周数（52或53），12月28日总在最后一周内
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// ISO周的周一，1月4日总在第1周内
+func isoWeekStart(year, week int) time.Time {
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, (week-1)*7-offset)
+}
+
+// 周期名称，如 2024-W05、2024-02、2024-Q1、2024-H1、FY2024
+func periodLabel(periodType string, year, number int) string {
+	switch periodType {
+	case "week":
+		return fmt.Sprintf("%04d-W%02d", year, number)
+	case "month":
+		return fmt.Sprintf("%04d-%02d", year, number)
+	case "quarter":
+		return fmt.Sprintf("%04d-Q%d", year, number)
+	case "half_year":
+		return fmt.Sprintf("%04d-H%d", year, number)
+	case "fiscal_year":
+		return fmt.Sprintf("FY%04d", year)
+	default:
+		return fmt.Sprintf("%04d", year)
+	}
+}
+
+// 查询周期的起止日期：按ISO周、月、季度、半年、年或财年定位，或返回包含指定日期的周期
+func GetPeriod(req model.PeriodRequest) (*model.PeriodResponse, error) {
+	months, ok := PERIOD_MONTHS[req.Type]
+	if !ok && req.Type != "week" {
+		return nil, &model.ErrorResponse{Code: 3014, Message: "周期类型错误，应为week、month、quarter、half_year、year或fiscal_year"}
+	}
+
+	fiscalStartMonth := 0
+	if req.Type == "fiscal_year" {
+		fiscalStartMonth = req.FiscalStartMonth
+		if fiscalStartMonth == 0 {
+			fiscalStartMonth = 1
+		}
+		if fiscalStartMonth < 1 || fiscalStartMonth > 12 {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "财年起始月份必须在1-12之间"}
+		}
+	}
+
+	// 未指定日期和周期时使用今天
+	var date time.Time
+	hasDate := req.Date != "" || (req.Year == 0 && req.Number == 0)
+	if req.Date != "" {
+		if !utils.IsValidDateFormat(req.Date) {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "日期格式错误，应为YYYY-MM-DD"}
+		}
+		d, err := utils.ParseDate(req.Date)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "日期解析失败: " + err.Error()}
+		}
+		date = d
+	} else if hasDate {
+		now := time.Now().In(beijingZone)
+		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	// 定位周期的年份、序号和开始日期
+	year, number := req.Year, req.Number
+	var start, next time.Time
+	switch req.Type {
+	case "week":
+		if hasDate {
+			year, number = date.ISOWeek()
+		} else if year < 1 || year > 9999 {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "年份超出支持范围(1-9999)"}
+		} else if number < 1 || number > isoWeeksInYear(year) {
+			return nil, &model.ErrorResponse{Code: 3014, Message: fmt.Sprintf("%d年的ISO周数必须在1-%d之间", year, isoWeeksInYear(year))}
+		}
+		start = isoWeekStart(year, number)
+		next = start.AddDate(0, 0, 7)
+	case "fiscal_year":
+		// 财年以起始月份所在的公历年份命名
+		if hasDate {
+			year = date.Year()
+			if int(date.Month()) < fiscalStartMonth {
+				year--
+			}
+		} else if year < 1 || year > 9999 {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "年份超出支持范围(1-9999)"}
+		}
+		number = 0
+		start = time.Date(year, time.Month(fiscalStartMonth), 1, 0, 0, 0, 0, time.UTC)
+		next = start.AddDate(0, months, 0)
+	default:
+		count := 12 / months
+		if hasDate {
+			year = date.Year()
+			number = (int(date.Month())-1)/months + 1
+		} else if year < 1 || year > 9999 {
+			return nil, &model.ErrorResponse{Code: 3014, Message: "年份超出支持范围(1-9999)"}
+		} else if count == 1 {
+			number = 1
+		} else if number < 1 || number > count {
+			return nil, &model.ErrorResponse{Code: 3014, Message: fmt.Sprintf("%s序号必须在1-%d之间", PERIOD_NAMES[req.Type], count)}
+		}
+		start = time.Date(year, time.Month((number-1)*months+1), 1, 0, 0, 0, 0, time.UTC)
+		next = start.AddDate(0, months, 0)
+		if count == 1 {
+			number = 0
+		}
+	}
+
+	end := next.AddDate(0, 0, -1)
+	days := int(next.Sub(start).Hours() / 24)
+	previous := start.AddDate(0, -months, 0)
+	if req.Type == "week" {
+		previous = start.AddDate(0, 0, -7)
+	}
+
+	response := &model.PeriodResponse{
+		Type:             req.Type,
+		Year:             year,
+		Number:           number,
+		Label:            periodLabel(req.Type, year, number),
+		StartDate:        start.Format("2006-01-02"),
+		EndDate:          end.Format("2006-01-02"),
+		Days:             days,
+		FiscalStartMonth: fiscalStartMonth,
+		PreviousStart:    previous.Format("2006-01-02"),
+		NextStart:        next.Format("2006-01-02"),
+	}
+
+	if hasDate {
+		dayOfPeriod := int(date.Sub(start).Hours()/24) + 1
+		daysRemaining := days - dayOfPeriod
+		response.Date = date.Format("2006-01-02")
+		response.DayOfPeriod = &dayOfPeriod
+		response.DaysRemaining = &daysRemaining
+	}
+
+	return response, nil
+}
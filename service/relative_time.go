@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"time"
+)
+
+// 相对时间的最小单位
+var RELATIVE_GRANULARITIES = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// 相对日期的中文说法
+var relativeDayNamesZh = map[int]string{-2: "后天", -1: "明天", 0: "今天", 1: "昨天", 2: "前天"}
+
+// 相对时间格式化参数
+type relativeFormatter struct {
+	reference   time.Time
+	language    string
+	granularity string
+}
+
+// 根据请求参数创建相对时间格式化器，未指定参考时间时以当前时间为准
+func newRelativeFormatter(opts model.RelativeTimeOptions, loc *time.Location) (*relativeFormatter, error) {
+	f := &relativeFormatter{reference: time.Now().In(loc), language: opts.Language, granularity: opts.Granularity}
+	if f.language == "" {
+		f.language = "zh"
+	}
+	if f.language != "zh" && f.language != "en" {
+		return nil, fmt.Errorf("不支持的语言: %s，应为zh或en", opts.Language)
+	}
+	if f.granularity == "" {
+		f.granularity = "minute"
+	}
+	if _, ok := RELATIVE_GRANULARITIES[f.granularity]; !ok {
+		return nil, fmt.Errorf("不支持的粒度: %s，应为second、minute、hour或day", opts.Granularity)
+	}
+	if opts.ReferenceTime != nil {
+		t, _, err := parseTimeInput(opts.ReferenceTime, loc)
+		if err != nil {
+			return nil, fmt.Errorf("参考时间%s", err.Error())
+		}
+		f.reference = t.In(loc)
+	}
+	return f, nil
+}
+
+// 英文单位，数量不为1时使用复数
+func relativeUnitEn(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// 组合数量与方向，如 5分钟前、in 5 minutes
+func (f *relativeFormatter) phrase(n int, unitZh, unitEn string, past bool) string {
+	if f.language == "en" {
+		if past {
+			return relativeUnitEn(n, unitEn) + " ago"
+		}
+		return "in " + relativeUnitEn(n, unitEn)
+	}
+	if past {
+		return fmt.Sprintf("%d%s前", n, unitZh)
+	}
+	return fmt.Sprintf("%d%s后", n, unitZh)
+}
+
+// 格式化相对时间，如 刚刚、5分钟前、昨天 14:30、3 weeks ago
+func (f *relativeFormatter) format(t time.Time) string {
+	ref := f.reference.In(t.Location())
+	diff := ref.Sub(t)
+	past := diff >= 0
+	if diff < 0 {
+		diff = -diff
+	}
+
+	// 按日历计算相差天数，正数表示过去
+	refDay := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	dayDiff := int(refDay.Sub(day).Hours() / 24)
+	absDays := dayDiff
+	if absDays < 0 {
+		absDays = -absDays
+	}
+
+	if f.granularity != "day" {
+		switch {
+		case diff < RELATIVE_GRANULARITIES[f.granularity]:
+			if f.language == "en" {
+				if past {
+					return "just now"
+				}
+				return "in a moment"
+			}
+			if past {
+				return "刚刚"
+			}
+			return "即将"
+		case diff < time.Minute:
+			return f.phrase(int(diff/time.Second), "秒", "second", past)
+		case diff < time.Hour:
+			return f.phrase(int(diff/time.Minute), "分钟", "minute", past)
+		case dayDiff == 0:
+			return f.phrase(int(diff/time.Hour), "小时", "hour", past)
+		case f.language == "zh" && absDays <= 2:
+			return relativeDayNamesZh[dayDiff] + " " + t.Format("15:04")
+		case f.language == "en" && absDays == 1:
+			if past {
+				return "yesterday at " + t.Format("15:04")
+			}
+			return "tomorrow at " + t.Format("15:04")
+		}
+	} else {
+		switch {
+		case f.language == "zh" && absDays <= 2:
+			return relativeDayNamesZh[dayDiff]
+		case f.language == "en" && absDays == 0:
+			return "today"
+		case f.language == "en" && absDays == 1:
+			if past {
+				return "yesterday"
+			}
+			return "tomorrow"
+		}
+	}
+
+	if absDays < 7 {
+		return f.phrase(absDays, "天", "day", past)
+	}
+
+	// 超过一周按周、月、年计算
+	start, end := startOfDay(t), startOfDay(ref)
+	if !past {
+		start, end = end, start
+	}
+	calendar := calculateCalendarDiff(start, end)
+	months := calendar.Years*12 + calendar.Months
+	switch {
+	case months == 0:
+		return f.phrase(absDays/7, "周", "week", past)
+	case calendar.Years == 0:
+		return f.phrase(months, "个月", "month", past)
+	default:
+		return f.phrase(calendar.Years, "年", "year", past)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/renoz/toolbox-api/model"
+	"testing"
+)
+
+// relative仅时间转换和当前时间接口支持，其他接口应返回错误而不是忽略选项
+func TestRelativeOutputFormatRejected(t *testing.T) {
+	if _, err := AddTimeDuration(model.TimeAddRequest{TimeInput: "2024-01-01 00:00:00", Duration: "P1D", OutputFormat: "relative"}); err == nil {
+		t.Error("AddTimeDuration with relative output expected error")
+	}
+	if _, err := ExpandRRule(model.RRuleRequest{RRule: "FREQ=DAILY;COUNT=2", DTStart: "2024-01-01 00:00:00", OutputFormat: "relative"}); err == nil {
+		t.Error("ExpandRRule with relative output expected error")
+	}
+	resp, err := ConvertTime(model.TimeConvertRequest{TimeInput: "2024-01-01 00:00:00", OutputFormat: "relative", Timezone: "UTC",
+		RelativeTimeOptions: model.RelativeTimeOptions{ReferenceTime: "2024-01-01 00:05:00", Language: "en"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Converted != "5 minutes ago" {
+		t.Errorf("ConvertTime relative = %q, want %q", resp.Converted, "5 minutes ago")
+	}
+}
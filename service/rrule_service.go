@@ -0,0 +1,751 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 默认返回的重复次数及上限
+const (
+	defaultRRuleLimit = 100
+	maxRRuleLimit     = 1000
+)
+
+// 展开重复规则时最多检查的周期数和年数，防止不可能满足的规则无限循环
+const (
+	maxRRulePeriods     = 100000
+	maxRRuleSearchYears = 200
+)
+
+// 重复频率，按周期从大到小排列
+const (
+	freqYearly = iota
+	freqMonthly
+	freqWeekly
+	freqDaily
+	freqHourly
+	freqMinutely
+	freqSecondly
+)
+
+var RRULE_FREQUENCIES = map[string]int{
+	"YEARLY":   freqYearly,
+	"MONTHLY":  freqMonthly,
+	"WEEKLY":   freqWeekly,
+	"DAILY":    freqDaily,
+	"HOURLY":   freqHourly,
+	"MINUTELY": freqMinutely,
+	"SECONDLY": freqSecondly,
+}
+
+// iCalendar星期缩写
+var RRULE_WEEKDAYS = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// iCalendar日期时间格式，如 20240101T090000Z、20240101
+var icalDateTimePattern = regexp.MustCompile(`^(\d{8})(T\d{6}(Z)?)?$`)
+
+// BYDAY中的一项，如 MO、-1FR、2TU
+type rruleWeekday struct {
+	weekday time.Weekday
+	n       int // 第n个，负数为倒数，0表示每个
+}
+
+// 解析后的重复规则
+type rrule struct {
+	freq       int
+	interval   int
+	count      int
+	until      time.Time
+	bySecond   []int
+	byMinute   []int
+	byHour     []int
+	byDay      []rruleWeekday
+	byMonthDay []int
+	byYearDay  []int
+	byWeekNo   []int
+	byMonth    []int
+	bySetPos   []int
+	wkst       time.Weekday
+}
+
+// 解析iCalendar日期时间，返回是否仅为日期
+func parseICalDateTime(value string, loc *time.Location) (time.Time, bool, error) {
+	matches := icalDateTimePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return time.Time{}, false, fmt.Errorf("日期时间格式错误: %s，应为YYYYMMDD或YYYYMMDDTHHMMSS[Z]", value)
+	}
+	if matches[2] == "" {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if matches[3] != "" {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// 解析请求中的时间，支持iCalendar格式及parseTimeInput支持的格式
+func parseRRuleTime(input interface{}, loc *time.Location) (time.Time, bool, error) {
+	if s, ok := input.(string); ok {
+		s = strings.TrimSpace(s)
+		if icalDateTimePattern.MatchString(s) {
+			return parseICalDateTime(s, loc)
+		}
+		if utils.IsValidDateFormat(s) {
+			t, err := time.ParseInLocation("2006-01-02", s, loc)
+			return t, true, err
+		}
+	}
+	t, _, err := parseTimeInput(input, loc)
+	return t, false, err
+}
+
+// 解析逗号分隔的整数列表并检查范围，allowNegative表示允许负数（倒数）
+func parseRRuleInts(key, value string, min, max int, allowNegative bool) ([]int, error) {
+	result := []int{}
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("%s的值无效: %s", key, item)
+		}
+		abs := n
+		if allowNegative && n < 0 {
+			abs = -n
+		}
+		if abs < min || abs > max || (allowNegative && n == 0) {
+			return nil, fmt.Errorf("%s的值超出范围: %d", key, n)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// 解析RRULE字符串，如 FREQ=MONTHLY;BYDAY=-1FR
+func parseRRule(value string, loc *time.Location) (*rrule, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 6 && strings.EqualFold(value[:6], "RRULE:") {
+		value = value[6:]
+	}
+
+	r := &rrule{freq: -1, interval: 1, wkst: time.Monday}
+	hasUntil := false
+	for _, part := range strings.Split(value, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("规则格式错误: %s，应为KEY=VALUE", part)
+		}
+		key, val := strings.ToUpper(strings.TrimSpace(kv[0])), strings.ToUpper(strings.TrimSpace(kv[1]))
+
+		var err error
+		switch key {
+		case "FREQ":
+			freq, ok := RRULE_FREQUENCIES[val]
+			if !ok {
+				return nil, fmt.Errorf("不支持的FREQ: %s", val)
+			}
+			r.freq = freq
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(val)
+			if err != nil || r.interval <= 0 {
+				return nil, fmt.Errorf("INTERVAL必须是正整数: %s", val)
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+			if err != nil || r.count <= 0 {
+				return nil, fmt.Errorf("COUNT必须是正整数: %s", val)
+			}
+		case "UNTIL":
+			var dateOnly bool
+			r.until, dateOnly, err = parseICalDateTime(val, loc)
+			if err != nil {
+				return nil, fmt.Errorf("UNTIL%s", err.Error())
+			}
+			if dateOnly {
+				// 仅日期时包含当天
+				r.until = r.until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+			hasUntil = true
+		case "BYSECOND":
+			r.bySecond, err = parseRRuleInts(key, val, 0, 59, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseRRuleInts(key, val, 0, 59, false)
+		case "BYHOUR":
+			r.byHour, err = parseRRuleInts(key, val, 0, 23, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleInts(key, val, 1, 31, true)
+		case "BYYEARDAY":
+			r.byYearDay, err = parseRRuleInts(key, val, 1, 366, true)
+		case "BYWEEKNO":
+			r.byWeekNo, err = parseRRuleInts(key, val, 1, 53, true)
+		case "BYMONTH":
+			r.byMonth, err = parseRRuleInts(key, val, 1, 12, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleInts(key, val, 1, 366, true)
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				item = strings.TrimSpace(item)
+				if len(item) < 2 {
+					return nil, fmt.Errorf("BYDAY的值无效: %s", item)
+				}
+				weekday, ok := RRULE_WEEKDAYS[item[len(item)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("BYDAY的值无效: %s", item)
+				}
+				n := 0
+				if prefix := item[:len(item)-2]; prefix != "" {
+					n, err = strconv.Atoi(prefix)
+					if err != nil || n == 0 || n < -53 || n > 53 {
+						return nil, fmt.Errorf("BYDAY的值无效: %s", item)
+					}
+				}
+				r.byDay = append(r.byDay, rruleWeekday{weekday: weekday, n: n})
+			}
+		case "WKST":
+			weekday, ok := RRULE_WEEKDAYS[val]
+			if !ok {
+				return nil, fmt.Errorf("WKST的值无效: %s", val)
+			}
+			r.wkst = weekday
+		default:
+			return nil, fmt.Errorf("不支持的规则属性: %s", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.freq < 0 {
+		return nil, fmt.Errorf("缺少FREQ属性")
+	}
+	if hasUntil && r.count > 0 {
+		return nil, fmt.Errorf("COUNT和UNTIL不能同时使用")
+	}
+	if len(r.byWeekNo) > 0 && r.freq != freqYearly {
+		return nil, fmt.Errorf("BYWEEKNO仅可用于FREQ=YEARLY")
+	}
+	if len(r.byYearDay) > 0 && (r.freq == freqMonthly || r.freq == freqWeekly || r.freq == freqDaily) {
+		return nil, fmt.Errorf("BYYEARDAY不能用于FREQ=MONTHLY、WEEKLY或DAILY")
+	}
+	if len(r.byMonthDay) > 0 && r.freq == freqWeekly {
+		return nil, fmt.Errorf("BYMONTHDAY不能用于FREQ=WEEKLY")
+	}
+	for _, d := range r.byDay {
+		if d.n != 0 && r.freq != freqMonthly && r.freq != freqYearly {
+			return nil, fmt.Errorf("BYDAY带序号（如-1FR）仅可用于FREQ=MONTHLY或YEARLY")
+		}
+	}
+	return r, nil
+}
+
+// 格式化为规范的RRULE字符串
+func (r *rrule) String() string {
+	names := []string{"YEARLY", "MONTHLY", "WEEKLY", "DAILY", "HOURLY", "MINUTELY", "SECONDLY"}
+	weekdays := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+	joinInts := func(values []int) string {
+		items := make([]string, len(values))
+		for i, v := range values {
+			items[i] = strconv.Itoa(v)
+		}
+		return strings.Join(items, ",")
+	}
+
+	parts := []string{"FREQ=" + names[r.freq]}
+	if r.interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.interval))
+	}
+	if r.count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.count))
+	}
+	if !r.until.IsZero() {
+		parts = append(parts, "UNTIL="+r.until.UTC().Format("20060102T150405Z"))
+	}
+	lists := []struct {
+		key    string
+		values []int
+	}{
+		{"BYMONTH", r.byMonth}, {"BYWEEKNO", r.byWeekNo}, {"BYYEARDAY", r.byYearDay}, {"BYMONTHDAY", r.byMonthDay},
+	}
+	for _, list := range lists {
+		if len(list.values) > 0 {
+			parts = append(parts, list.key+"="+joinInts(list.values))
+		}
+	}
+	if len(r.byDay) > 0 {
+		items := make([]string, len(r.byDay))
+		for i, d := range r.byDay {
+			items[i] = weekdays[d.weekday]
+			if d.n != 0 {
+				items[i] = strconv.Itoa(d.n) + items[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(items, ","))
+	}
+	lists = []struct {
+		key    string
+		values []int
+	}{
+		{"BYHOUR", r.byHour}, {"BYMINUTE", r.byMinute}, {"BYSECOND", r.bySecond}, {"BYSETPOS", r.bySetPos},
+	}
+	for _, list := range lists {
+		if len(list.values) > 0 {
+			parts = append(parts, list.key+"="+joinInts(list.values))
+		}
+	}
+	if r.wkst != time.Monday {
+		parts = append(parts, "WKST="+weekdays[r.wkst])
+	}
+	return strings.Join(parts, ";")
+}
+
+// 整数列表是否包含v
+func containsInt(values []int, v int) bool {
+	for _, item := range values {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// 某年第1周的开始日期（按WKST，包含至少4天的第一周为第1周）
+func rruleWeekOneStart(year int, wkst time.Weekday) time.Time {
+	jan1 := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	diff := (int(jan1.Weekday()) - int(wkst) + 7) % 7
+	start := jan1.AddDate(0, 0, -diff)
+	if 7-diff < 4 {
+		start = start.AddDate(0, 0, 7)
+	}
+	return start
+}
+
+// 某天在所属周年中的周数及该周年的总周数
+func rruleWeekNumber(day time.Time, wkst time.Weekday) (int, int) {
+	year := day.Year()
+	start := rruleWeekOneStart(year, wkst)
+	if day.Before(start) {
+		year--
+		start = rruleWeekOneStart(year, wkst)
+	} else if next := rruleWeekOneStart(year+1, wkst); !day.Before(next) {
+		year++
+		start = next
+	}
+	week := int(day.Sub(start).Hours()/24)/7 + 1
+	total := int(rruleWeekOneStart(year+1, wkst).Sub(start).Hours()/24) / 7
+	return week, total
+}
+
+// 日期是否满足规则中的日期限定条件
+func (r *rrule) matchDay(day time.Time) bool {
+	if len(r.byMonth) > 0 && !containsInt(r.byMonth, int(day.Month())) {
+		return false
+	}
+
+	yearDays := time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	monthDays := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if len(r.byWeekNo) > 0 {
+		week, total := rruleWeekNumber(day, r.wkst)
+		if !containsInt(r.byWeekNo, week) && !containsInt(r.byWeekNo, week-total-1) {
+			return false
+		}
+	}
+	if len(r.byYearDay) > 0 {
+		yd := day.YearDay()
+		if !containsInt(r.byYearDay, yd) && !containsInt(r.byYearDay, yd-yearDays-1) {
+			return false
+		}
+	}
+	if len(r.byMonthDay) > 0 {
+		md := day.Day()
+		if !containsInt(r.byMonthDay, md) && !containsInt(r.byMonthDay, md-monthDays-1) {
+			return false
+		}
+	}
+	if len(r.byDay) > 0 {
+		// 带序号时，MONTHLY或指定了BYMONTH的YEARLY按月计数，否则按年计数
+		inMonth := r.freq == freqMonthly || len(r.byMonth) > 0
+		matched := false
+		for _, d := range r.byDay {
+			if d.weekday != day.Weekday() {
+				continue
+			}
+			if d.n == 0 {
+				matched = true
+				break
+			}
+			index, total := day.YearDay(), yearDays
+			if inMonth {
+				index, total = day.Day(), monthDays
+			}
+			nth := (index-1)/7 + 1
+			nthLast := -((total-index)/7 + 1)
+			if d.n == nth || d.n == nthLast {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// 根据开始时间补全规则中未指定的默认值
+func (r *rrule) applyDefaults(dtstart time.Time) {
+	hasDayRule := len(r.byWeekNo) > 0 || len(r.byYearDay) > 0 || len(r.byMonthDay) > 0 || len(r.byDay) > 0
+	switch r.freq {
+	case freqYearly:
+		if !hasDayRule {
+			if len(r.byMonth) == 0 {
+				r.byMonth = []int{int(dtstart.Month())}
+			}
+			r.byMonthDay = []int{dtstart.Day()}
+		} else if len(r.byWeekNo) > 0 && len(r.byDay) == 0 && len(r.byYearDay) == 0 && len(r.byMonthDay) == 0 {
+			r.byDay = []rruleWeekday{{weekday: dtstart.Weekday()}}
+		}
+	case freqMonthly:
+		if len(r.byMonthDay) == 0 && len(r.byDay) == 0 && len(r.byYearDay) == 0 {
+			r.byMonthDay = []int{dtstart.Day()}
+		}
+	case freqWeekly:
+		if len(r.byDay) == 0 {
+			r.byDay = []rruleWeekday{{weekday: dtstart.Weekday()}}
+		}
+	}
+}
+
+// 时间字段取值：周期内的固定值需满足限定条件，否则使用BY*或开始时间的值
+func rruleTimeValues(byValues []int, periodValue int, isPeriodUnit bool, defaultValue int) []int {
+	if isPeriodUnit {
+		if len(byValues) > 0 && !containsInt(byValues, periodValue) {
+			return nil
+		}
+		return []int{periodValue}
+	}
+	if len(byValues) > 0 {
+		values := append([]int{}, byValues...)
+		sort.Ints(values)
+		return values
+	}
+	return []int{defaultValue}
+}
+
+// 生成某个周期内的候选日期（使用UTC表示日历日期）
+func (r *rrule) periodDays(cursor time.Time) []time.Time {
+	var first time.Time
+	var days int
+	switch r.freq {
+	case freqYearly:
+		first = time.Date(cursor.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		days = time.Date(cursor.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	case freqMonthly:
+		first = time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, time.UTC)
+		days = first.AddDate(0, 1, -1).Day()
+	case freqWeekly:
+		first = time.Date(cursor.Year(), cursor.Month(), cursor.Day(), 0, 0, 0, 0, time.UTC)
+		days = 7
+	default:
+		first = time.Date(cursor.Year(), cursor.Month(), cursor.Day(), 0, 0, 0, 0, time.UTC)
+		days = 1
+	}
+
+	result := []time.Time{}
+	for i := 0; i < days; i++ {
+		day := first.AddDate(0, 0, i)
+		if r.matchDay(day) {
+			result = append(result, day)
+		}
+	}
+	return result
+}
+
+// 计算下一个周期的起点（cursor为UTC表示的墙上时间）
+func (r *rrule) nextPeriod(cursor time.Time) time.Time {
+	switch r.freq {
+	case freqYearly:
+		return cursor.AddDate(r.interval, 0, 0)
+	case freqMonthly:
+		return cursor.AddDate(0, r.interval, 0)
+	case freqWeekly:
+		return cursor.AddDate(0, 0, 7*r.interval)
+	case freqDaily:
+		return cursor.AddDate(0, 0, r.interval)
+	case freqHourly:
+		return cursor.Add(time.Duration(r.interval) * time.Hour)
+	case freqMinutely:
+		return cursor.Add(time.Duration(r.interval) * time.Minute)
+	default:
+		return cursor.Add(time.Duration(r.interval) * time.Second)
+	}
+}
+
+// 展开重复规则，visit返回false时停止
+func (r *rrule) expand(dtstart time.Time, visit func(time.Time) bool) {
+	loc := dtstart.Location()
+	r.applyDefaults(dtstart)
+
+	// 第一个周期的起点：按墙上时间对齐到周期开始
+	y, m, d := dtstart.Date()
+	var cursor time.Time
+	switch r.freq {
+	case freqYearly:
+		cursor = time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	case freqMonthly:
+		cursor = time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case freqWeekly:
+		diff := (int(dtstart.Weekday()) - int(r.wkst) + 7) % 7
+		cursor = time.Date(y, m, d-diff, 0, 0, 0, 0, time.UTC)
+	case freqDaily:
+		cursor = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	case freqHourly:
+		cursor = time.Date(y, m, d, dtstart.Hour(), 0, 0, 0, time.UTC)
+	case freqMinutely:
+		cursor = time.Date(y, m, d, dtstart.Hour(), dtstart.Minute(), 0, 0, time.UTC)
+	default:
+		cursor = time.Date(y, m, d, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, time.UTC)
+	}
+
+	emitted := 0
+	for i := 0; i < maxRRulePeriods && cursor.Year() <= y+maxRRuleSearchYears; i++ {
+		hours := rruleTimeValues(r.byHour, cursor.Hour(), r.freq >= freqHourly, dtstart.Hour())
+		minutes := rruleTimeValues(r.byMinute, cursor.Minute(), r.freq >= freqMinutely, dtstart.Minute())
+		seconds := rruleTimeValues(r.bySecond, cursor.Second(), r.freq >= freqSecondly, dtstart.Second())
+
+		var instances []time.Time
+		if len(hours) > 0 && len(minutes) > 0 && len(seconds) > 0 {
+			for _, day := range r.periodDays(cursor) {
+				for _, h := range hours {
+					for _, mi := range minutes {
+						for _, s := range seconds {
+							instances = append(instances, time.Date(day.Year(), day.Month(), day.Day(), h, mi, s, 0, loc))
+						}
+					}
+				}
+			}
+		}
+
+		// BYSETPOS在周期内按序号选取
+		if len(r.bySetPos) > 0 && len(instances) > 0 {
+			selected := []time.Time{}
+			for _, pos := range r.bySetPos {
+				index := pos - 1
+				if pos < 0 {
+					index = len(instances) + pos
+				}
+				if index >= 0 && index < len(instances) {
+					selected = append(selected, instances[index])
+				}
+			}
+			sort.Slice(selected, func(a, b int) bool { return selected[a].Before(selected[b]) })
+			instances = selected
+		}
+
+		for j, t := range instances {
+			if j > 0 && t.Equal(instances[j-1]) {
+				continue
+			}
+			if t.Before(dtstart) {
+				continue
+			}
+			if !r.until.IsZero() && t.After(r.until) {
+				return
+			}
+			if !visit(t) {
+				return
+			}
+			emitted++
+			if r.count > 0 && emitted >= r.count {
+				return
+			}
+		}
+
+		cursor = r.nextPeriod(cursor)
+	}
+}
+
+// 展开RRULE重复规则
+func ExpandRRule(req model.RRuleRequest) (*model.RRuleResponse, error) {
+	// 规则文本可包含DTSTART、RRULE、EXDATE多行（iCalendar格式）
+	ruleText := ""
+	timezone := req.Timezone
+	var dtstartValue interface{} = req.DTStart
+	exdateValues := append([]interface{}{}, req.ExDates...)
+	exdateZones := make([]string, len(exdateValues)) // 各排除日期的TZID，为空时使用规则时区
+	for _, line := range strings.Split(strings.ReplaceAll(req.RRule, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		upper := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(upper, "DTSTART"), strings.HasPrefix(upper, "EXDATE"):
+			idx := strings.LastIndex(line, ":")
+			if idx < 0 {
+				return nil, &model.ErrorResponse{Code: 3010, Message: "格式错误: " + line}
+			}
+			value := line[idx+1:]
+			tzid := ""
+			for _, param := range strings.Split(line[:idx], ";")[1:] {
+				kv := strings.SplitN(param, "=", 2)
+				if len(kv) == 2 && strings.EqualFold(kv[0], "TZID") {
+					tzid = kv[1]
+				}
+			}
+			if strings.HasPrefix(upper, "DTSTART") {
+				if dtstartValue == nil {
+					dtstartValue = value
+				}
+				// 未指定时区时使用DTSTART的TZID参数
+				if timezone == "" {
+					timezone = tzid
+				}
+				continue
+			}
+			for _, item := range strings.Split(value, ",") {
+				exdateValues = append(exdateValues, item)
+				exdateZones = append(exdateZones, tzid)
+			}
+		case strings.HasPrefix(upper, "RRULE:") || !strings.Contains(line, ":"):
+			ruleText = line
+		default:
+			return nil, &model.ErrorResponse{Code: 3010, Message: "不支持的属性: " + line}
+		}
+	}
+	if ruleText == "" {
+		return nil, &model.ErrorResponse{Code: 3010, Message: "缺少RRULE规则"}
+	}
+
+	loc, timezoneInfo, err := resolveLocation(timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	rule, err := parseRRule(ruleText, loc)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3010, Message: "RRULE" + err.Error()}
+	}
+
+	// 请求中的count、until覆盖规则中的设置
+	if req.Count > 0 {
+		rule.count, rule.until = req.Count, time.Time{}
+	}
+	if req.Until != nil {
+		until, dateOnly, err := parseRRuleTime(req.Until, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3010, Message: "结束时间" + err.Error()}
+		}
+		if dateOnly {
+			// 仅日期时包含当天
+			until = until.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		rule.until, rule.count = until, 0
+	}
+
+	// 开始时间默认当前时间（精确到秒）
+	dtstart := time.Now().In(loc).Truncate(time.Second)
+	if dtstartValue != nil {
+		dtstart, _, err = parseRRuleTime(dtstartValue, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3010, Message: "开始时间" + err.Error()}
+		}
+	}
+	dtstart = dtstart.In(loc)
+
+	// 排除日期：仅日期时排除当天所有重复
+	excludedTimes := map[int64]bool{}
+	excludedDays := map[string]bool{}
+	for i, value := range exdateValues {
+		// 带TZID的排除时间按该时区解析
+		exdateLoc := loc
+		if exdateZones[i] != "" {
+			exdateLoc, _, err = resolveLocation(exdateZones[i], nil)
+			if err != nil {
+				return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+			}
+		}
+		t, dateOnly, err := parseRRuleTime(value, exdateLoc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3010, Message: "排除日期" + err.Error()}
+		}
+		if dateOnly {
+			excludedDays[t.Format("2006-01-02")] = true
+		} else {
+			excludedTimes[t.Unix()] = true
+		}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRRuleLimit
+	}
+	if limit > maxRRuleLimit {
+		return nil, &model.ErrorResponse{Code: 3010, Message: fmt.Sprintf("limit不能超过%d", maxRRuleLimit)}
+	}
+
+	format := req.OutputFormat
+	if format == "" {
+		format = "iso"
+	}
+	if err := checkOutputFormat(format); err != nil {
+		return nil, &model.ErrorResponse{Code: 3010, Message: err.Error()}
+	}
+
+	normalized := "RRULE:" + rule.String()
+	occurrences := []model.RRuleOccurrence{}
+	excluded := 0
+	truncated := false
+	rule.expand(dtstart, func(t time.Time) bool {
+		if excludedTimes[t.Unix()] || excludedDays[t.Format("2006-01-02")] {
+			excluded++
+			return true
+		}
+		if len(occurrences) >= limit {
+			truncated = true
+			return false
+		}
+		occurrences = append(occurrences, model.RRuleOccurrence{
+			Time:        formatTime(t, format, req.CustomFormat),
+			Timestamp:   t.Unix(),
+			WeekdayName: utils.WeekdayNames[t.Weekday()],
+		})
+		return true
+	})
+
+	until := ""
+	if !rule.until.IsZero() {
+		until = rule.until.In(loc).Format(time.RFC3339)
+	}
+
+	return &model.RRuleResponse{
+		RRule:        normalized,
+		DTStart:      dtstart.Format(time.RFC3339),
+		Until:        until,
+		Count:        rule.count,
+		Occurrences:  occurrences,
+		Total:        len(occurrences),
+		Excluded:     excluded,
+		Truncated:    truncated,
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+	}, nil
+}
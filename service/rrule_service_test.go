@@ -0,0 +1,89 @@
+package service
+
+import (
+	"github.com/renoz/toolbox-api/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpandRRule(t *testing.T) {
+	cases := []struct {
+		rrule   string
+		dtstart string
+		exdates []interface{}
+		want    []string
+	}{
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", "2024-01-01 09:00:00", nil,
+			[]string{"2024-01-26 09:00:00", "2024-02-23 09:00:00", "2024-03-29 09:00:00"}},
+		// 不存在的日期跳过，不顺延到月末
+		{"FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", "2024-01-31 09:00:00", nil,
+			[]string{"2024-01-31 09:00:00", "2024-03-31 09:00:00", "2024-05-31 09:00:00"}},
+		{"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;COUNT=2", "2024-02-29 09:00:00", nil,
+			[]string{"2024-02-29 09:00:00", "2028-02-29 09:00:00"}},
+		// 以下为RFC 5545中的示例
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4", "1997-09-02 09:00:00", nil,
+			[]string{"1997-09-02 09:00:00", "1997-09-04 09:00:00", "1997-09-16 09:00:00", "1997-09-18 09:00:00"}},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3", "1997-09-02 09:00:00", nil,
+			[]string{"1998-02-13 09:00:00", "1998-03-13 09:00:00", "1998-11-13 09:00:00"}},
+		{"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO;COUNT=3", "1997-05-12 09:00:00", nil,
+			[]string{"1997-05-12 09:00:00", "1998-05-11 09:00:00", "1999-05-17 09:00:00"}},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3", "1997-09-29 09:00:00", nil,
+			[]string{"1997-09-30 09:00:00", "1997-10-31 09:00:00", "1997-11-28 09:00:00"}},
+		// 仅日期的EXDATE排除当天，COUNT包含被排除的次数
+		{"FREQ=DAILY;COUNT=4", "2024-01-01 09:00:00", []interface{}{"2024-01-02"},
+			[]string{"2024-01-01 09:00:00", "2024-01-03 09:00:00", "2024-01-04 09:00:00"}},
+		{"FREQ=DAILY;BYHOUR=9,17;COUNT=3", "2024-01-01 09:00:00", nil,
+			[]string{"2024-01-01 09:00:00", "2024-01-01 17:00:00", "2024-01-02 09:00:00"}},
+	}
+	for _, c := range cases {
+		resp, err := ExpandRRule(model.RRuleRequest{
+			RRule:        c.rrule,
+			DTStart:      c.dtstart,
+			ExDates:      c.exdates,
+			OutputFormat: "datetime",
+			Timezone:     "UTC",
+		})
+		if err != nil {
+			t.Errorf("ExpandRRule(%s) error: %v", c.rrule, err)
+			continue
+		}
+		got := make([]string, 0, len(resp.Occurrences))
+		for _, o := range resp.Occurrences {
+			got = append(got, o.Time)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ExpandRRule(%s) = %v, want %v", c.rrule, got, c.want)
+		}
+	}
+}
+
+func TestExpandRRuleInvalid(t *testing.T) {
+	for _, rule := range []string{"FREQ=SOMETIMES", "FREQ=DAILY;COUNT=2;UNTIL=20240101", "FREQ=MONTHLY;BYMONTHDAY=32", "BYDAY=MO"} {
+		if _, err := ExpandRRule(model.RRuleRequest{RRule: rule, DTStart: "2024-01-01 00:00:00", Timezone: "UTC"}); err == nil {
+			t.Errorf("ExpandRRule(%s) expected error", rule)
+		}
+	}
+}
+
+// EXDATE的TZID与DTSTART不同时按各自时区比较
+func TestExpandRRuleExDateTZID(t *testing.T) {
+	if _, err := time.LoadLocation("America/New_York"); err != nil {
+		t.Skip("时区数据不可用")
+	}
+	resp, err := ExpandRRule(model.RRuleRequest{
+		RRule:        "DTSTART;TZID=America/New_York:20240101T090000\nRRULE:FREQ=DAILY;COUNT=3\nEXDATE;TZID=Europe/London:20240102T140000",
+		OutputFormat: "datetime",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, 0, len(resp.Occurrences))
+	for _, o := range resp.Occurrences {
+		got = append(got, o.Time)
+	}
+	want := []string{"2024-01-01 09:00:00", "2024-01-03 09:00:00"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandRRule with EXDATE;TZID = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultRestShift   = "休"
+	maxShiftSchedule   = 3660 // 最多生成的排班天数
+	maxShiftPeopleSize = 500
+)
+
+// 生成轮换排班表
+func GenerateShiftSchedule(req model.ShiftScheduleRequest) (*model.ShiftScheduleResponse, error) {
+	format := strings.ToLower(req.Format)
+	if format != "" && format != "json" && format != "csv" {
+		return nil, &model.ErrorResponse{Code: 3016, Message: "不支持的输出格式: " + req.Format + "，可选值: json, csv"}
+	}
+
+	restShift := req.RestShift
+	if restShift == "" {
+		restShift = defaultRestShift
+	}
+
+	// 验证人员和班次
+	if len(req.People) > maxShiftPeopleSize {
+		return nil, &model.ErrorResponse{Code: 3016, Message: fmt.Sprintf("人员数量不能超过%d", maxShiftPeopleSize)}
+	}
+	people := make([]string, len(req.People))
+	seen := map[string]bool{}
+	for i, person := range req.People {
+		person = strings.TrimSpace(person)
+		if person == "" {
+			return nil, &model.ErrorResponse{Code: 3016, Message: fmt.Sprintf("第%d个人员名称不能为空", i+1)}
+		}
+		if seen[person] {
+			return nil, &model.ErrorResponse{Code: 3016, Message: "人员名称重复: " + person}
+		}
+		seen[person] = true
+		people[i] = person
+	}
+	pattern := make([]string, len(req.Pattern))
+	for i, shift := range req.Pattern {
+		shift = strings.TrimSpace(shift)
+		if shift == "" {
+			return nil, &model.ErrorResponse{Code: 3016, Message: fmt.Sprintf("第%d个班次名称不能为空", i+1)}
+		}
+		pattern[i] = shift
+	}
+
+	// 每人的起始位置，默认依次错开一个班次
+	if len(req.Offsets) > 0 && len(req.Offsets) != len(people) {
+		return nil, &model.ErrorResponse{Code: 3016, Message: "offsets数量必须与人员数量一致"}
+	}
+	positions := make([]int, len(people))
+	for i := range people {
+		offset := i
+		if len(req.Offsets) > 0 {
+			offset = req.Offsets[i]
+		}
+		positions[i] = (offset%len(pattern) + len(pattern)) % len(pattern)
+	}
+
+	// 休息日规则与工作日计算一致，连续生产时传入rest_day_pattern为0000000
+	rule, err := newWorkdayRule(req.WorkdayOptions)
+	if err != nil {
+		return nil, workdayRuleError(err, 3016)
+	}
+
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3016, Message: "开始日期格式错误: " + err.Error()}
+	}
+	endDate, err := utils.ParseDate(req.EndDate)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3016, Message: "结束日期格式错误: " + err.Error()}
+	}
+	if endDate.Before(startDate) {
+		return nil, &model.ErrorResponse{Code: 3016, Message: "结束日期不能早于开始日期"}
+	}
+	totalDays := int(endDate.Sub(startDate).Hours()/24) + 1
+	if totalDays > maxShiftSchedule {
+		return nil, &model.ErrorResponse{Code: 3016, Message: fmt.Sprintf("排班天数不能超过%d天", maxShiftSchedule)}
+	}
+
+	totals := make([]model.ShiftPersonTotal, len(people))
+	for i, person := range people {
+		totals[i] = model.ShiftPersonTotal{Person: person, Shifts: map[string]int{}}
+	}
+
+	days := make([]model.ShiftScheduleDay, 0, totalDays)
+	step := 0
+	current := startDate
+	for i := 0; i < totalDays; i++ {
+		day := rule.classify(current)
+		scheduleDay := model.ShiftScheduleDay{
+			Date:        current.Format("2006-01-02"),
+			Weekday:     int(current.Weekday()),
+			WeekdayName: utils.WeekdayNames[current.Weekday()],
+			IsRest:      day.IsRest,
+			RestType:    day.RestType,
+			Reason:      day.Reason,
+			Assignments: make([]model.ShiftAssignment, 0, len(people)),
+			ByShift:     map[string][]string{},
+		}
+
+		for p, person := range people {
+			// 休息日所有人休息
+			shift := restShift
+			if !day.IsRest {
+				shift = pattern[(positions[p]+step)%len(pattern)]
+			}
+			scheduleDay.Assignments = append(scheduleDay.Assignments, model.ShiftAssignment{Person: person, Shift: shift})
+			scheduleDay.ByShift[shift] = append(scheduleDay.ByShift[shift], person)
+
+			totals[p].Shifts[shift]++
+			if shift == restShift {
+				totals[p].RestDays++
+			} else {
+				totals[p].WorkDays++
+			}
+		}
+		days = append(days, scheduleDay)
+
+		// 休息日暂停轮换时，下一个工作日接着当前班次继续
+		if !day.IsRest || !req.PauseOnRest {
+			step++
+		}
+		current = current.AddDate(0, 0, 1)
+	}
+
+	return &model.ShiftScheduleResponse{
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+		TotalDays: totalDays,
+		Pattern:   pattern,
+		RestShift: restShift,
+		Days:      days,
+		Totals:    totals,
+	}, nil
+}
+
+// 排班表导出为CSV：先按日期列出每人班次，空一行后为每人统计，返回文件内容、Content-Type和文件名
+func ExportShiftScheduleCSV(schedule *model.ShiftScheduleResponse) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+	// 写入BOM，便于Excel正确识别中文
+	buf.WriteString("\ufeff")
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"日期", "星期"}
+	for _, total := range schedule.Totals {
+		header = append(header, total.Person)
+	}
+	header = append(header, "备注")
+	writer.Write(header)
+
+	for _, day := range schedule.Days {
+		row := []string{day.Date, day.WeekdayName}
+		for _, assignment := range day.Assignments {
+			row = append(row, assignment.Shift)
+		}
+		row = append(row, day.Reason)
+		writer.Write(row)
+	}
+
+	// 统计部分按班次在轮换中首次出现的顺序排列
+	shifts := []string{}
+	seen := map[string]bool{}
+	for _, shift := range append(append([]string{}, schedule.Pattern...), schedule.RestShift) {
+		if !seen[shift] {
+			seen[shift] = true
+			shifts = append(shifts, shift)
+		}
+	}
+
+	writer.Write(nil)
+	writer.Write(append([]string{"人员", "上班天数", "休息天数"}, shifts...))
+	for _, total := range schedule.Totals {
+		row := []string{total.Person, strconv.Itoa(total.WorkDays), strconv.Itoa(total.RestDays)}
+		for _, shift := range shifts {
+			row = append(row, strconv.Itoa(total.Shifts[shift]))
+		}
+		writer.Write(row)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, "", "", &model.ErrorResponse{Code: 9000, Message: "导出排班表失败: " + err.Error()}
+	}
+
+	filename := fmt.Sprintf("shift_schedule_%s_%s.csv", schedule.StartDate, schedule.EndDate)
+	return buf.Bytes(), "text/csv; charset=utf-8", filename, nil
+}
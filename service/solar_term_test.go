@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+// 与紫金山天文台公布的交节时刻（北京时间）比较，截断级数的误差应在1分钟内
+func TestSolarTermTime(t *testing.T) {
+	cases := []struct {
+		year, index int
+		want        string
+	}{
+		{2023, 0, "2023-01-05 23:04:39"},  // 小寒
+		{2023, 2, "2023-02-04 10:42:21"},  // 立春
+		{2023, 5, "2023-03-21 05:24:24"},  // 春分
+		{2023, 11, "2023-06-21 22:57:37"}, // 夏至
+		{2023, 23, "2023-12-22 11:27:09"}, // 冬至
+		{2024, 0, "2024-01-06 04:49:09"},
+		{2024, 2, "2024-02-04 16:27:07"},
+		{2024, 5, "2024-03-20 11:06:15"},
+		{2024, 11, "2024-06-21 04:50:46"},
+		{2024, 23, "2024-12-21 17:20:20"},
+	}
+	for _, c := range cases {
+		want, _ := time.ParseInLocation("2006-01-02 15:04:05", c.want, beijingZone)
+		got := solarTermTime(c.year, c.index)
+		if diff := got.Sub(want); diff < -time.Minute || diff > time.Minute {
+			t.Errorf("solarTermTime(%d, %s) = %s, want %s", c.year, SOLAR_TERM_NAMES[c.index], got.Format("2006-01-02 15:04:05"), c.want)
+		}
+	}
+}
+
+// 整个数据范围内每个节气都应落在对应月份，相邻节气间隔约15天
+func TestSolarTermRange(t *testing.T) {
+	var previous time.Time
+	for year := LUNAR_MIN_YEAR; year <= LUNAR_MAX_YEAR; year++ {
+		for index := 0; index < 24; index++ {
+			term := solarTermTime(year, index)
+			if term.Year() != year || int(term.Month()) != index/2+1 || term.Day() < 3 || term.Day() > 24 {
+				t.Fatalf("solarTermTime(%d, %s) = %s", year, SOLAR_TERM_NAMES[index], term.Format("2006-01-02"))
+			}
+			if !previous.IsZero() {
+				if gap := term.Sub(previous).Hours() / 24; gap < 14 || gap > 16.5 {
+					t.Fatalf("solarTermTime(%d, %s) is %.2f days after the previous term", year, SOLAR_TERM_NAMES[index], gap)
+				}
+			}
+			previous = term
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // 字符串分割
@@ -293,4 +294,135 @@ func ConvertDateToChineseSimple(req model.ConvertDateSimpleRequest) (*model.Conv
 		OriginalDate:  req.DateStr,
 		ConvertedDate: yearChineseBuilder.String() + "年" + monthChineseBuilder.String() + dayChineseBuilder.String(),
 	}, nil
-} 
\ No newline at end of file
+}
+
+// 中文数字字符对应的数值（大写、普通及〇、两等变体）
+func chineseDigitValue(r rune) (int, bool) {
+	for i, upper := range utils.ChineseNumbersUpper {
+		if string(r) == upper || string(r) == utils.ChineseNumbersSimple[i] {
+			return i, true
+		}
+	}
+	switch r {
+	case '〇', '○', 'Ｏ':
+		return 0, true
+	case '两', '貮':
+		return 2, true
+	case '参':
+		return 3, true
+	}
+	if r >= '0' && r <= '9' {
+		return int(r - '0'), true
+	}
+	return 0, false
+}
+
+// 解析日期中的月、日数字，如 零壹、壹拾、零壹拾、十一、二十、廿一
+func parseChineseDatePart(s string) (int, bool) {
+	s = strings.Replace(s, "廿", "二十", 1)
+	s = strings.Replace(s, "卅", "三十", 1)
+	s = strings.Replace(s, "拾", "十", -1)
+
+	// 去除前导零，如 零壹、零壹拾
+	runes := []rune(s)
+	for len(runes) > 1 {
+		if v, ok := chineseDigitValue(runes[0]); ok && v == 0 {
+			runes = runes[1:]
+			continue
+		}
+		break
+	}
+	if len(runes) == 0 {
+		return 0, false
+	}
+
+	// 阿拉伯数字
+	if n, err := strconv.Atoi(string(runes)); err == nil {
+		return n, true
+	}
+
+	tenIndex := -1
+	for i, r := range runes {
+		if r == '十' {
+			tenIndex = i
+			break
+		}
+	}
+	if tenIndex < 0 {
+		if len(runes) != 1 {
+			return 0, false
+		}
+		return chineseDigitValue(runes[0])
+	}
+
+	// 十位与个位均最多一位数字
+	if tenIndex > 1 || len(runes)-tenIndex-1 > 1 {
+		return 0, false
+	}
+	tens, units := 1, 0
+	if tenIndex == 1 {
+		v, ok := chineseDigitValue(runes[0])
+		if !ok {
+			return 0, false
+		}
+		tens = v
+	}
+	if tenIndex < len(runes)-1 {
+		v, ok := chineseDigitValue(runes[tenIndex+1])
+		if !ok {
+			return 0, false
+		}
+		units = v
+	}
+	return tens*10 + units, true
+}
+
+// 中文日期格式
+var chineseDatePattern = regexp.MustCompile(`^(.+?)年(.+?)月(.+?)[日号]$`)
+
+// 中文日期解析为YYYY-MM-DD，支持大写（贰零贰肆年零壹月零壹拾日）和普通格式（二〇二四年一月十日）
+func ParseChineseDate(req model.ParseChineseDateRequest) (*model.ConvertDateResponse, error) {
+	input := strings.Join(strings.Fields(req.DateStr), "")
+	matches := chineseDatePattern.FindStringSubmatch(input)
+	if matches == nil {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "日期格式错误，应为X年X月X日"}
+	}
+
+	// 年份逐位转换
+	year := 0
+	yearRunes := []rune(matches[1])
+	for _, r := range yearRunes {
+		v, ok := chineseDigitValue(r)
+		if !ok {
+			return nil, &model.ErrorResponse{Code: 4001, Message: "年份格式错误: " + matches[1]}
+		}
+		year = year*10 + v
+	}
+	if len(yearRunes) != 4 {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "年份应为四位数字: " + matches[1]}
+	}
+
+	month, ok := parseChineseDatePart(matches[2])
+	if !ok {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "月份格式错误: " + matches[2]}
+	}
+	day, ok := parseChineseDatePart(matches[3])
+	if !ok {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "日期格式错误: " + matches[3]}
+	}
+
+	// 校验日期是否存在
+	if month < 1 || month > 12 {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "月份无效: " + strconv.Itoa(month) + "月"}
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if day < 1 || date.Day() != day {
+		return nil, &model.ErrorResponse{Code: 4001, Message: "日期无效: " + strconv.Itoa(year) + "年" + strconv.Itoa(month) + "月没有" + strconv.Itoa(day) + "日"}
+	}
+
+	return &model.ConvertDateResponse{
+		OriginalDate:  req.DateStr,
+		ConvertedDate: date.Format("2006-01-02"),
+	}, nil
+}
+
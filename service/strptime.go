@@ -0,0 +1,342 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 复合指令展开，与formatWithPythonFormat中的%c、%x、%X保持一致
+var strptimeCompositeReplacer = strings.NewReplacer(
+	"%%", "%%",
+	"%c", "%a %b %d %H:%M:%S %Y",
+	"%x", "%m/%d/%y",
+	"%X", "%H:%M:%S",
+)
+
+// strptime解析过程中收集的各时间字段
+type strptimeFields struct {
+	year, month, day          int
+	hour, minute, second      int
+	nanosecond                int
+	yearDay                   int
+	weekday                   int // -1表示未指定
+	hour12, pm                bool
+	hasMonth, hasDay, hasZone bool
+	offset                    int
+}
+
+// 按Python strptime格式解析时间字符串，为formatWithPythonFormat的逆操作。
+// 未指定的字段按Python的默认值处理（1900-01-01 00:00:00），不含%z时按loc解释
+func parseWithPythonFormat(value, pythonFormat string, loc *time.Location) (time.Time, error) {
+	if pythonFormat == "" {
+		return time.Time{}, fmt.Errorf("输入格式不能为空")
+	}
+	format := strptimeCompositeReplacer.Replace(pythonFormat)
+
+	fields := &strptimeFields{year: 1900, month: 1, day: 1, weekday: -1}
+	pos := 0
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+
+		// 格式中的空白匹配输入中任意数量的空白
+		if c == ' ' || c == '\t' || c == '\n' {
+			for pos < len(value) && unicode.IsSpace(rune(value[pos])) {
+				pos++
+			}
+			continue
+		}
+
+		// 普通字符需完全匹配
+		if c != '%' {
+			_, size := utf8.DecodeRuneInString(format[i:])
+			literal := format[i : i+size]
+			if !strings.HasPrefix(value[pos:], literal) {
+				return time.Time{}, fmt.Errorf("第%d个字符处期望\"%s\"，实际为%s", runePosition(value, pos), literal, inputSnippet(value[pos:]))
+			}
+			pos += size
+			i += size - 1
+			continue
+		}
+
+		if i+1 >= len(format) {
+			return time.Time{}, fmt.Errorf("输入格式以单独的%%结尾")
+		}
+		i++
+		_, size := utf8.DecodeRuneInString(format[i:])
+		directive := "%" + format[i:i+size]
+		i += size - 1
+
+		n, err := fields.parseDirective(directive, value[pos:])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("第%d个字符处解析%s失败: %s", runePosition(value, pos), directive, err.Error())
+		}
+		pos += n
+	}
+
+	if pos < len(value) {
+		return time.Time{}, fmt.Errorf("第%d个字符起存在多余内容%s", runePosition(value, pos), inputSnippet(value[pos:]))
+	}
+
+	return fields.build(loc)
+}
+
+// 解析单个格式指令，返回消耗的字节数
+func (f *strptimeFields) parseDirective(directive, s string) (int, error) {
+	switch directive {
+	case "%Y":
+		v, n, err := parseStrptimeNumber(s, 4, 4, 0, 9999, "4位年份")
+		f.year = v
+		return n, err
+	case "%y":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 0, 99, "2位年份(00-99)")
+		// 与Python一致：69-99为1969-1999，00-68为2000-2068
+		if v < 69 {
+			f.year = 2000 + v
+		} else {
+			f.year = 1900 + v
+		}
+		return n, err
+	case "%m":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 1, 12, "月份(01-12)")
+		f.month, f.hasMonth = v, true
+		return n, err
+	case "%d":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 1, 31, "日期(01-31)")
+		f.day, f.hasDay = v, true
+		return n, err
+	case "%j":
+		v, n, err := parseStrptimeNumber(s, 1, 3, 1, 366, "年内天数(001-366)")
+		f.yearDay = v
+		return n, err
+	case "%H":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 0, 23, "小时(00-23)")
+		f.hour = v
+		return n, err
+	case "%I":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 1, 12, "12小时制小时(01-12)")
+		f.hour, f.hour12 = v, true
+		return n, err
+	case "%M":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 0, 59, "分钟(00-59)")
+		f.minute = v
+		return n, err
+	case "%S":
+		v, n, err := parseStrptimeNumber(s, 1, 2, 0, 59, "秒(00-59)")
+		f.second = v
+		return n, err
+	case "%f":
+		v, n, err := parseStrptimeNumber(s, 1, 6, 0, 999999, "微秒(1-6位数字)")
+		if err != nil {
+			return n, err
+		}
+		// 不足6位时右侧补零，如 .5 表示500000微秒
+		for i := n; i < 9; i++ {
+			v *= 10
+		}
+		f.nanosecond = v
+		return n, nil
+	case "%w":
+		v, n, err := parseStrptimeNumber(s, 1, 1, 0, 6, "星期(0-6，0为周日)")
+		f.weekday = v
+		return n, err
+	case "%u":
+		v, n, err := parseStrptimeNumber(s, 1, 1, 1, 7, "ISO星期(1-7，7为周日)")
+		f.weekday = v % 7
+		return n, err
+	case "%p":
+		return f.parseMeridiem(s)
+	case "%B", "%b":
+		for _, full := range []bool{true, false} {
+			for m := time.January; m <= time.December; m++ {
+				name := m.String()
+				if !full {
+					name = name[:3]
+				}
+				if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+					f.month, f.hasMonth = int(m), true
+					return len(name), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("期望英文月份名称(如Jan、January)，实际为%s", inputSnippet(s))
+	case "%A", "%a":
+		for _, full := range []bool{true, false} {
+			for d := time.Sunday; d <= time.Saturday; d++ {
+				name := d.String()
+				if !full {
+					name = name[:3]
+				}
+				if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+					f.weekday = int(d)
+					return len(name), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("期望英文星期名称(如Mon、Monday)，实际为%s", inputSnippet(s))
+	case "%z":
+		return f.parseZoneOffset(s)
+	case "%Z":
+		for _, name := range []string{"UTC", "GMT", "Z"} {
+			if len(s) >= len(name) && strings.EqualFold(s[:len(name)], name) {
+				f.offset, f.hasZone = 0, true
+				return len(name), nil
+			}
+		}
+		return 0, fmt.Errorf("仅支持UTC、GMT，其他时区请使用%%z或input_timezone，实际为%s", inputSnippet(s))
+	case "%%":
+		if strings.HasPrefix(s, "%") {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("期望\"%%\"，实际为%s", inputSnippet(s))
+	case "%U", "%W", "%V":
+		return 0, fmt.Errorf("暂不支持按周数解析")
+	default:
+		return 0, fmt.Errorf("不支持的格式指令")
+	}
+}
+
+// 解析上午/下午标记，支持AM/PM及中文上午/下午
+func (f *strptimeFields) parseMeridiem(s string) (int, error) {
+	candidates := []struct {
+		text string
+		pm   bool
+	}{
+		{"AM", false}, {"PM", true}, {"上午", false}, {"下午", true},
+	}
+	for _, c := range candidates {
+		if len(s) >= len(c.text) && strings.EqualFold(s[:len(c.text)], c.text) {
+			f.pm = c.pm
+			return len(c.text), nil
+		}
+	}
+	return 0, fmt.Errorf("期望AM/PM或上午/下午，实际为%s", inputSnippet(s))
+}
+
+// 解析UTC偏移，支持 +0800、+08:00、-0330 及 Z
+func (f *strptimeFields) parseZoneOffset(s string) (int, error) {
+	if strings.HasPrefix(s, "Z") || strings.HasPrefix(s, "z") {
+		f.offset, f.hasZone = 0, true
+		return 1, nil
+	}
+	if s == "" || (s[0] != '+' && s[0] != '-') {
+		return 0, fmt.Errorf("期望UTC偏移(如+0800、+08:00)，实际为%s", inputSnippet(s))
+	}
+	n := 1
+	hours, size, err := parseStrptimeNumber(s[n:], 2, 2, 0, 14, "偏移小时数")
+	if err != nil {
+		return 0, fmt.Errorf("期望UTC偏移(如+0800、+08:00)，实际为%s", inputSnippet(s))
+	}
+	n += size
+	if n < len(s) && s[n] == ':' {
+		n++
+	}
+	minutes, size, err := parseStrptimeNumber(s[n:], 2, 2, 0, 59, "偏移分钟数")
+	if err != nil {
+		return 0, fmt.Errorf("期望UTC偏移(如+0800、+08:00)，实际为%s", inputSnippet(s))
+	}
+	n += size
+
+	f.offset = hours*3600 + minutes*60
+	if s[0] == '-' {
+		f.offset = -f.offset
+	}
+	f.hasZone = true
+	return n, nil
+}
+
+// 根据解析出的字段构建时间并校验日期有效性
+func (f *strptimeFields) build(loc *time.Location) (time.Time, error) {
+	if f.hasZone {
+		loc = time.FixedZone(fixedZoneName(f.offset), f.offset)
+	}
+
+	hour := f.hour
+	if f.hour12 {
+		hour = f.hour % 12
+		if f.pm {
+			hour += 12
+		}
+	}
+
+	month, day := f.month, f.day
+	if f.yearDay != 0 && !f.hasMonth && !f.hasDay {
+		// 仅给出年内天数时换算为月日
+		date := time.Date(f.year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, f.yearDay-1)
+		if date.Year() != f.year {
+			return time.Time{}, fmt.Errorf("%d年没有第%d天", f.year, f.yearDay)
+		}
+		month, day = int(date.Month()), date.Day()
+	}
+
+	t := time.Date(f.year, time.Month(month), day, hour, f.minute, f.second, f.nanosecond, loc)
+	if t.Day() != day || int(t.Month()) != month {
+		return time.Time{}, fmt.Errorf("日期无效: %04d-%02d-%02d", f.year, month, day)
+	}
+	if f.yearDay != 0 && t.YearDay() != f.yearDay {
+		return time.Time{}, fmt.Errorf("年内天数%d与日期%s不符", f.yearDay, t.Format("2006-01-02"))
+	}
+	if f.weekday >= 0 && int(t.Weekday()) != f.weekday {
+		return time.Time{}, fmt.Errorf("星期与日期不符: %s是%s", t.Format("2006-01-02"), t.Weekday().String())
+	}
+	return t, nil
+}
+
+// 解析minDigits到maxDigits位数字并校验范围，返回数值和消耗的字节数
+func parseStrptimeNumber(s string, minDigits, maxDigits, min, max int, expect string) (int, int, error) {
+	n := 0
+	for n < len(s) && n < maxDigits && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	if n < minDigits {
+		return 0, 0, fmt.Errorf("期望%s，实际为%s", expect, inputSnippet(s))
+	}
+	v, _ := strconv.Atoi(s[:n])
+	if v < min || v > max {
+		return 0, 0, fmt.Errorf("%s超出范围: %s", expect, s[:n])
+	}
+	return v, n, nil
+}
+
+// 字节位置转换为从1开始的字符位置
+func runePosition(s string, pos int) int {
+	return utf8.RuneCountInString(s[:pos]) + 1
+}
+
+// 输入片段，用于错误提示
+func inputSnippet(s string) string {
+	if s == "" {
+		return "输入结尾"
+	}
+	runes := []rune(s)
+	if len(runes) > 10 {
+		return "\"" + string(runes[:10]) + "...\""
+	}
+	return "\"" + s + "\""
+}
+
+// 按指定格式解析时间输入，数字输入按整数字符串处理（如20261017）
+func parseTimeInputWithFormat(input interface{}, pythonFormat string, loc *time.Location) (time.Time, string, error) {
+	var value string
+	switch v := input.(type) {
+	case string:
+		value = v
+	case float64:
+		value = strconv.FormatInt(int64(v), 10)
+	case int:
+		value = strconv.Itoa(v)
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	default:
+		return time.Time{}, "", fmt.Errorf("不支持的时间输入格式")
+	}
+
+	t, err := parseWithPythonFormat(value, pythonFormat, loc)
+	if err != nil {
+		return time.Time{}, value, fmt.Errorf("时间与输入格式%s不匹配: %s", pythonFormat, err.Error())
+	}
+	return t, value, nil
+}
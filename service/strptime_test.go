@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWithPythonFormat(t *testing.T) {
+	cases := []struct {
+		value, format, want string
+	}{
+		{"05.03.2024 14:30", "%d.%m.%Y %H:%M", "2024-03-05T14:30:00Z"},
+		{"2024-03-05T14:30:00+0530", "%Y-%m-%dT%H:%M:%S%z", "2024-03-05T14:30:00+05:30"},
+		{"Tue Mar  5 09:07:03 2024", "%c", "2024-03-05T09:07:03Z"},
+		{"03/05/24", "%x", "2024-03-05T00:00:00Z"},
+		{"2024-065", "%Y-%j", "2024-03-05T00:00:00Z"},
+		{"5 March 2024 2:30 PM", "%d %B %Y %I:%M %p", "2024-03-05T14:30:00Z"},
+		{"12:00:00.123456", "%H:%M:%S.%f", "1900-01-01T12:00:00.123456Z"},
+		{"2024年3月5日", "%Y年%m月%d日", "2024-03-05T00:00:00Z"},
+		{"5%", "%d%%", "1900-01-05T00:00:00Z"},
+		{"69-01-01", "%y-%m-%d", "1969-01-01T00:00:00Z"},
+		{"68-01-01", "%y-%m-%d", "2068-01-01T00:00:00Z"},
+	}
+	for _, c := range cases {
+		got, err := parseWithPythonFormat(c.value, c.format, time.UTC)
+		if err != nil {
+			t.Errorf("parseWithPythonFormat(%q, %q) error: %v", c.value, c.format, err)
+			continue
+		}
+		if got.Format(time.RFC3339Nano) != c.want {
+			t.Errorf("parseWithPythonFormat(%q, %q) = %s, want %s", c.value, c.format, got.Format(time.RFC3339Nano), c.want)
+		}
+	}
+}
+
+func TestParseWithPythonFormatInvalid(t *testing.T) {
+	cases := []struct {
+		value, format string
+	}{
+		{"2024-02-30", "%Y-%m-%d"},        // 日期不存在
+		{"2024-03-05 extra", "%Y-%m-%d"},  // 多余内容
+		{"Mon 2024-03-05", "%a %Y-%m-%d"}, // 星期与日期不符
+		{"2024-13-01", "%Y-%m-%d"},
+		{"25:00", "%H:%M"},
+	}
+	for _, c := range cases {
+		if _, err := parseWithPythonFormat(c.value, c.format, time.UTC); err == nil {
+			t.Errorf("parseWithPythonFormat(%q, %q) expected error", c.value, c.format)
+		}
+	}
+}
+
+// 按同一格式输出后再解析应得到原时间
+func TestPythonFormatRoundTrip(t *testing.T) {
+	loc := time.FixedZone("", -(3*3600 + 30*60))
+	original := time.Date(2024, 12, 31, 23, 59, 58, 123456000, loc)
+	for _, format := range []string{
+		"%Y-%m-%d %H:%M:%S.%f%z",
+		"%A, %d %B %Y %I:%M:%S.%f %p %z",
+		"%y%j %H%M%S.%f %z",
+	} {
+		text := formatWithPythonFormat(original, format, nil)
+		parsed, err := parseWithPythonFormat(text, format, time.UTC)
+		if err != nil {
+			t.Errorf("parse %q with %q error: %v", text, format, err)
+			continue
+		}
+		if !parsed.Equal(original) {
+			t.Errorf("round trip %q with %q = %s, want %s", text, format, parsed, original)
+		}
+	}
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 自定义格式支持的风格
+var FORMAT_STYLES = []string{"python", "moment", "java", "go"}
+
+// 语言区域的月份、星期及上下午名称
+type formatLocale struct {
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string // 以周日开始
+	shortWeekdays [7]string
+	am, pm        string
+	daySuffix     string // Moment的Do格式的日期后缀，为空时使用英文序数
+	human         string // human输出格式（Python格式）
+}
+
+var englishFormatLocale = &formatLocale{
+	months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	am:            "AM",
+	pm:            "PM",
+	human:         "%A, %B %-d, %Y %-I:%M:%S %p",
+}
+
+var chineseMonths = [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"}
+var numericMonths = [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"}
+
+// 支持的语言区域，键为小写
+var FORMAT_LOCALES = map[string]*formatLocale{
+	"en-us": englishFormatLocale,
+	"zh-cn": {
+		months:        chineseMonths,
+		shortMonths:   numericMonths,
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:            "上午",
+		pm:            "下午",
+		daySuffix:     "日",
+		human:         "%Y年%-m月%-d日 %A %H:%M:%S",
+	},
+	"zh-tw": {
+		months:        chineseMonths,
+		shortMonths:   numericMonths,
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		am:            "上午",
+		pm:            "下午",
+		daySuffix:     "日",
+		human:         "%Y年%-m月%-d日 %A %p%I:%M:%S",
+	},
+	"ja-jp": {
+		months:        numericMonths,
+		shortMonths:   numericMonths,
+		weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:            "午前",
+		pm:            "午後",
+		daySuffix:     "日",
+		human:         "%Y年%-m月%-d日(%a) %H時%M分%S秒",
+	},
+}
+
+// 解析语言区域，不区分大小写，zh_CN与zh-CN等价；为空时返回nil，按英文名称输出
+func resolveFormatLocale(name string) (*formatLocale, error) {
+	if name == "" {
+		return nil, nil
+	}
+	locale, ok := FORMAT_LOCALES[strings.ToLower(strings.Replace(name, "_", "-", -1))]
+	if !ok {
+		return nil, fmt.Errorf("不支持的语言区域: %s，可选值: zh-CN, zh-TW, en-US, ja-JP", name)
+	}
+	return locale, nil
+}
+
+// 校验自定义格式风格，为空时按Python格式处理
+func resolveFormatStyle(style string) (string, error) {
+	name := strings.ToLower(style)
+	if name == "" {
+		return "python", nil
+	}
+	for _, s := range FORMAT_STYLES {
+		if s == name {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的格式风格: %s，可选值: %s", style, strings.Join(FORMAT_STYLES, ", "))
+}
+
+// 格式中的一段：字面文本或时间字段
+type formatToken struct {
+	literal string
+	field   string // 字段名，为空表示字面文本
+}
+
+// Python指令对应的字段，%-d等不补零写法对应带"-"前缀的字段
+var pythonFormatFields = map[byte]string{
+	'Y': "Y", 'y': "y", 'm': "m", 'B': "B", 'b': "b", 'd': "d", 'j': "j",
+	'U': "U", 'W': "W", 'V': "V", 'G': "G", 'A': "A", 'a': "a", 'w': "w", 'u': "u",
+	'H': "H", 'I': "I", 'p': "p", 'M': "M", 'S': "S", 'f': "f6",
+	'z': "z", 'Z': "Z", 'c': "c", 'x': "x", 'X': "X",
+}
+
+// Moment.js格式符及对应字段，按长度降序匹配
+var momentFormatTokens = []struct {
+	token string
+	field string
+}{
+	{"YYYY", "Y"}, {"GGGG", "G"}, {"MMMM", "B"}, {"DDDD", "j"}, {"dddd", "A"},
+	{"SSSSSSSSS", "f9"}, {"SSSSSS", "f6"}, {"SSS", "f3"},
+	{"MMM", "b"}, {"DDD", "-j"}, {"ddd", "a"},
+	{"YY", "y"}, {"MM", "m"}, {"DD", "d"}, {"Do", "Do"}, {"dd", "a"}, {"WW", "V"}, {"HH", "H"}, {"hh", "I"},
+	{"mm", "M"}, {"ss", "S"}, {"ZZ", "z"}, {"SS", "f2"},
+	{"Q", "Q"}, {"M", "-m"}, {"D", "-d"}, {"d", "w"}, {"E", "u"}, {"W", "-V"}, {"H", "-H"}, {"h", "-I"},
+	{"m", "-M"}, {"s", "-S"}, {"S", "f1"}, {"A", "p"}, {"a", "P"}, {"Z", "z:"}, {"X", "unix"}, {"x", "unix_ms"},
+}
+
+// 拆分Python strftime格式，未知指令按原样保留
+func tokenizePythonFormat(format string) []formatToken {
+	tokens := []formatToken{}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, formatToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			literal.WriteByte(format[i])
+			continue
+		}
+		next := format[i+1]
+		if next == '%' {
+			literal.WriteByte('%')
+			i++
+			continue
+		}
+		// %-d、%-m等不补零写法
+		if next == '-' && i+2 < len(format) {
+			if field, ok := pythonFormatFields[format[i+2]]; ok && strings.Contains("dmjHIMS", string(format[i+2])) {
+				flush()
+				tokens = append(tokens, formatToken{field: "-" + field})
+				i += 2
+				continue
+			}
+		}
+		if field, ok := pythonFormatFields[next]; ok {
+			flush()
+			tokens = append(tokens, formatToken{field: field})
+			i++
+			continue
+		}
+		literal.WriteByte('%')
+	}
+	flush()
+	return tokens
+}
+
+// 拆分Moment.js格式，方括号内为字面文本，如 YYYY-MM-DD [at] HH:mm
+func tokenizeMomentFormat(format string) []formatToken {
+	tokens := []formatToken{}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, formatToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			if end := strings.IndexByte(format[i+1:], ']'); end >= 0 {
+				literal.WriteString(format[i+1 : i+1+end])
+				i += end + 2
+				continue
+			}
+		}
+		matched := false
+		for _, t := range momentFormatTokens {
+			if strings.HasPrefix(format[i:], t.token) {
+				flush()
+				tokens = append(tokens, formatToken{field: t.field})
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			literal.WriteByte(format[i])
+			i++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Java格式中连续相同字母对应的字段，不支持的字母返回false
+func javaFormatField(letter byte, count int) (string, bool) {
+	pick := func(short, long string) string {
+		if count >= 2 {
+			return long
+		}
+		return short
+	}
+	switch letter {
+	case 'y', 'u':
+		if count == 2 {
+			return "y", true
+		}
+		return "Y", true
+	case 'Y':
+		// 周所在的年份
+		if count == 2 {
+			return "g", true
+		}
+		return "G", true
+	case 'M', 'L':
+		switch {
+		case count >= 4:
+			return "B", true
+		case count == 3:
+			return "b", true
+		}
+		return pick("-m", "m"), true
+	case 'd':
+		return pick("-d", "d"), true
+	case 'D':
+		return pick("-j", "j"), true
+	case 'E':
+		if count >= 4 {
+			return "A", true
+		}
+		return "a", true
+	case 'H':
+		return pick("-H", "H"), true
+	case 'h':
+		return pick("-I", "I"), true
+	case 'm':
+		return pick("-M", "M"), true
+	case 's':
+		return pick("-S", "S"), true
+	case 'S':
+		if count > 9 {
+			count = 9
+		}
+		return "f" + strconv.Itoa(count), true
+	case 'a':
+		return "p", true
+	case 'Z':
+		return "z", true
+	case 'X', 'x':
+		if count >= 3 {
+			return "z:", true
+		}
+		return "z", true
+	case 'z':
+		return "Z", true
+	case 'w':
+		return "-V", true
+	case 'Q', 'q':
+		return "Q", true
+	}
+	return "", false
+}
+
+// 拆分Java DateTimeFormatter/SimpleDateFormat格式，单引号内为字面文本，两个连续单引号表示单引号本身；
+// 与Java一致，引号外不支持的字母返回错误
+func tokenizeJavaFormat(format string) ([]formatToken, error) {
+	tokens := []formatToken{}
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, formatToken{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); {
+		c := format[i]
+		if c == '\'' {
+			if i+1 < len(format) && format[i+1] == '\'' {
+				literal.WriteByte('\'')
+				i += 2
+				continue
+			}
+			// 引号内两个连续单引号同样表示单引号，缺少结束引号时取到末尾
+			for i++; i < len(format); i++ {
+				if format[i] == '\'' {
+					if i+1 < len(format) && format[i+1] == '\'' {
+						literal.WriteByte('\'')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				literal.WriteByte(format[i])
+			}
+			continue
+		}
+
+		count := 1
+		for i+count < len(format) && format[i+count] == c {
+			count++
+		}
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			field, ok := javaFormatField(c, count)
+			if !ok {
+				return nil, fmt.Errorf("不支持的Java格式字母: %c，字面文本请用单引号括起", c)
+			}
+			flush()
+			tokens = append(tokens, formatToken{field: field})
+			i += count
+			continue
+		}
+		literal.WriteString(format[i : i+count])
+		i += count
+	}
+	flush()
+	return tokens, nil
+}
+
+// 年内周数，firstDay为每周第一天（0为周日，1为周一），第一个完整周之前为第0周
+func yearWeekNumber(t time.Time, firstDay int) int {
+	jan1 := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	offset := (7 - ((int(jan1.Weekday()) - firstDay + 7) % 7)) % 7
+	if t.YearDay()-1 < offset {
+		return 0
+	}
+	return (t.YearDay()-1-offset)/7 + 1
+}
+
+// 英文序数后缀，如 1st、2nd、11th
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// 输出单个时间字段，月份、星期及上下午按locale输出
+func renderFormatField(t time.Time, field string, locale *formatLocale) string {
+	if locale == nil {
+		locale = englishFormatLocale
+	}
+	// 不补零的字段
+	if strings.HasPrefix(field, "-") {
+		value := renderFormatField(t, field[1:], locale)
+		trimmed := strings.TrimLeft(value, "0")
+		if trimmed == "" {
+			return "0"
+		}
+		return trimmed
+	}
+	// 秒的小数部分，f后为位数
+	if len(field) == 2 && field[0] == 'f' && field[1] >= '1' && field[1] <= '9' {
+		return fmt.Sprintf("%09d", t.Nanosecond())[:field[1]-'0']
+	}
+
+	switch field {
+	case "Y":
+		return fmt.Sprintf("%04d", t.Year())
+	case "y":
+		return fmt.Sprintf("%02d", t.Year()%100)
+	case "G":
+		year, _ := t.ISOWeek()
+		return fmt.Sprintf("%04d", year)
+	case "g":
+		year, _ := t.ISOWeek()
+		return fmt.Sprintf("%02d", year%100)
+	case "Q":
+		return strconv.Itoa((int(t.Month())-1)/3 + 1)
+	case "m":
+		return fmt.Sprintf("%02d", int(t.Month()))
+	case "B":
+		return locale.months[t.Month()-1]
+	case "b":
+		return locale.shortMonths[t.Month()-1]
+	case "d":
+		return fmt.Sprintf("%02d", t.Day())
+	case "Do":
+		if locale.daySuffix != "" {
+			return strconv.Itoa(t.Day()) + locale.daySuffix
+		}
+		return strconv.Itoa(t.Day()) + ordinalSuffix(t.Day())
+	case "j":
+		return fmt.Sprintf("%03d", t.YearDay())
+	case "U":
+		return fmt.Sprintf("%02d", yearWeekNumber(t, 0))
+	case "W":
+		return fmt.Sprintf("%02d", yearWeekNumber(t, 1))
+	case "V":
+		_, week := t.ISOWeek()
+		return fmt.Sprintf("%02d", week)
+	case "A":
+		return locale.weekdays[t.Weekday()]
+	case "a":
+		return locale.shortWeekdays[t.Weekday()]
+	case "w":
+		return strconv.Itoa(int(t.Weekday()))
+	case "u":
+		// ISO周几（1-7，周一到周日）
+		weekday := int(t.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		return strconv.Itoa(weekday)
+	case "H":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "I":
+		hour12 := t.Hour() % 12
+		if hour12 == 0 {
+			hour12 = 12
+		}
+		return fmt.Sprintf("%02d", hour12)
+	case "p":
+		if t.Hour() < 12 {
+			return locale.am
+		}
+		return locale.pm
+	case "P":
+		if t.Hour() < 12 {
+			return strings.ToLower(locale.am)
+		}
+		return strings.ToLower(locale.pm)
+	case "M":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "S":
+		return fmt.Sprintf("%02d", t.Second())
+	case "z":
+		return t.Format("-0700")
+	case "z:":
+		return t.Format("-07:00")
+	case "Z":
+		return t.Format("MST")
+	case "c":
+		return t.Format("Mon Jan 2 15:04:05 2006")
+	case "x":
+		return t.Format("01/02/06")
+	case "X":
+		return t.Format("15:04:05")
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unix_ms":
+		return strconv.FormatInt(t.UnixNano()/1e6, 10)
+	}
+	return ""
+}
+
+// 按拆分后的格式输出时间
+func renderFormatTokens(t time.Time, tokens []formatToken, locale *formatLocale) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		if token.field == "" {
+			b.WriteString(token.literal)
+		} else {
+			b.WriteString(renderFormatField(t, token.field, locale))
+		}
+	}
+	return b.String()
+}
+
+// 按Python strftime格式输出时间，locale为nil时按英文名称输出
+func formatWithPythonFormat(t time.Time, pythonFormat string, locale *formatLocale) string {
+	return renderFormatTokens(t, tokenizePythonFormat(pythonFormat), locale)
+}
+
+// 按指定风格的自定义格式输出时间，style为空时按Python格式处理；go风格固定输出英文名称
+func formatWithStyle(t time.Time, customFormat, style string, locale *formatLocale) (string, error) {
+	style, err := resolveFormatStyle(style)
+	if err != nil {
+		return "", err
+	}
+	switch style {
+	case "moment":
+		return renderFormatTokens(t, tokenizeMomentFormat(customFormat), locale), nil
+	case "java":
+		tokens, err := tokenizeJavaFormat(customFormat)
+		if err != nil {
+			return "", err
+		}
+		return renderFormatTokens(t, tokens, locale), nil
+	case "go":
+		return t.Format(customFormat), nil
+	default:
+		return formatWithPythonFormat(t, customFormat, locale), nil
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"github.com/renoz/toolbox-api/model"
+	"testing"
+	"time"
+)
+
+var formatTestTime = time.Date(2024, 3, 5, 14, 7, 9, 123456789, time.UTC)
+
+func TestFormatWithStyle(t *testing.T) {
+	cases := []struct {
+		style, format, want string
+	}{
+		{"python", "%Y-%m-%d %H:%M:%S", "2024-03-05 14:07:09"},
+		{"", "100%% %d", "100% 05"},
+		{"python", "%-d/%-m %-H %-j %j", "5/3 14 65 065"},
+		{"python", "%f", "123456"},
+		// 未知指令及末尾的%按原样输出
+		{"python", "%Q %k %-k %", "%Q %k %-k %"},
+		{"moment", "YYYY-MM-DD [at] HH:mm", "2024-03-05 at 14:07"},
+		{"moment", "[YYYY] YYYY [[Q]]", "YYYY 2024 [Q]"},
+		{"moment", "dddd ddd dd d", "Tuesday Tue Tue 2"},
+		{"moment", "Do MMM, h:mm a", "5th Mar, 2:07 pm"},
+		{"moment", "X SSS", "1709647629 123"},
+		{"java", "yyyy-MM-dd HH:mm:ss.SSS", "2024-03-05 14:07:09.123"},
+		{"java", "yyyy年MM月dd日 'at' HH:mm", "2024年03月05日 at 14:07"},
+		{"java", "h 'o''clock' a", "2 o'clock PM"},
+		{"java", "''yy''", "'24'"},
+		{"java", "EEEE EEE, uuuu YYYY", "Tuesday Tue, 2024 2024"},
+		{"go", "Mon 2006-01-02 15:04", "Tue 2024-03-05 14:07"},
+	}
+	for _, c := range cases {
+		got, err := formatWithStyle(formatTestTime, c.format, c.style, nil)
+		if err != nil {
+			t.Errorf("formatWithStyle(%q, %s) error: %v", c.format, c.style, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("formatWithStyle(%q, %s) = %q, want %q", c.format, c.style, got, c.want)
+		}
+	}
+}
+
+func TestFormatWithStyleWeekYear(t *testing.T) {
+	// 2024-12-30为2025年第1周
+	date := time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)
+	if got, _ := formatWithStyle(date, "uuuu YYYY YY 'W'w", "java", nil); got != "2024 2025 25 W1" {
+		t.Errorf("java week year = %q, want %q", got, "2024 2025 25 W1")
+	}
+	if got, _ := formatWithStyle(date, "YYYY GGGG", "moment", nil); got != "2024 2025" {
+		t.Errorf("moment week year = %q, want %q", got, "2024 2025")
+	}
+}
+
+func TestFormatWithStyleInvalid(t *testing.T) {
+	cases := []struct {
+		style, format string
+	}{
+		{"java", "yyyy-MM-dd T HH:mm"}, // 引号外不支持的字母
+		{"java", "yyyy-MM-dd at HH:mm"},
+		{"strftime", "%Y"},
+	}
+	for _, c := range cases {
+		if got, err := formatWithStyle(formatTestTime, c.format, c.style, nil); err == nil {
+			t.Errorf("formatWithStyle(%q, %s) = %q, expected error", c.format, c.style, got)
+		}
+	}
+
+	// 非custom格式同样校验format_style
+	_, err := ConvertTime(model.TimeConvertRequest{TimeInput: "2024-03-05 14:07:09", OutputFormat: "datetime", FormatStyle: "strftime"})
+	if err == nil {
+		t.Error("ConvertTime with unknown format_style expected error")
+	}
+	if _, err := GetCurrentTime("datetime", "UTC", nil, "", "strftime", "", model.RelativeTimeOptions{}); err == nil {
+		t.Error("GetCurrentTime with unknown format_style expected error")
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	cases := []struct {
+		locale, names, human string
+	}{
+		{"zh-CN", "星期二 周二 三月 3月 下午", "2024年3月5日 星期二 14:07:09"},
+		{"zh_tw", "星期二 週二 三月 3月 下午", "2024年3月5日 星期二 下午02:07:09"},
+		{"ja-JP", "火曜日 火 3月 3月 午後", "2024年3月5日(火) 14時07分09秒"},
+		{"en-US", "Tuesday Tue March Mar PM", "Tuesday, March 5, 2024 2:07:09 PM"},
+	}
+	for _, c := range cases {
+		locale, err := resolveFormatLocale(c.locale)
+		if err != nil {
+			t.Errorf("resolveFormatLocale(%s) error: %v", c.locale, err)
+			continue
+		}
+		if got := formatWithPythonFormat(formatTestTime, "%A %a %B %b %p", locale); got != c.names {
+			t.Errorf("%s names = %q, want %q", c.locale, got, c.names)
+		}
+		if got := formatTimeInLocale(formatTestTime, "human", "", locale); got != c.human {
+			t.Errorf("%s human = %q, want %q", c.locale, got, c.human)
+		}
+	}
+	if _, err := resolveFormatLocale("fr-FR"); err == nil {
+		t.Error("resolveFormatLocale(fr-FR) expected error")
+	}
+}
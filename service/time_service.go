@@ -1,14 +1,17 @@
 package service
 
 import (
+	"encoding/json"
 	"github.com/renoz/toolbox-api/model"
 	"github.com/renoz/toolbox-api/utils"
 	"fmt"
 	"strconv"
-	"strings"
 	"time"
 )
 
+// 批量时间转换单次最多处理的项数
+const maxBatchConvertItems = 1000
+
 // 支持的时区
 var SUPPORTED_TIMEZONES = map[string]string{
 	"asia_shanghai":   "Asia/Shanghai",   // 东8区
@@ -31,17 +34,26 @@ var TIMEZONE_DESCRIPTIONS = map[string]string{
 	"America/Los_Angeles": "西8区",
 }
 
-// 获取时区信息
-func getTimezoneInfo(timezoneName string, tzOffset *int) (*model.TimezoneInfo, error) {
+// 工作日偏移的最大天数
+const maxWorkdayOffset = 10000
+
+// 获取时区信息，tzOffset支持小时数（可为小数）、分钟数或"+05:30"格式
+func getTimezoneInfo(timezoneName string, tzOffset interface{}) (*model.TimezoneInfo, error) {
 	var loc *time.Location
 	var err error
 	var offset int
 	
+	// 解析时区偏移量
+	offsetSeconds, err := parseTzOffset(tzOffset)
+	if err != nil {
+		return nil, err
+	}
+	
 	// 优先使用偏移量
-	if tzOffset != nil {
-		// 使用指定的小时偏移量
-		offset = *tzOffset * 3600
-		loc = time.FixedZone("FixedZone", offset)
+	if offsetSeconds != nil {
+		// 使用指定的偏移量，时区名称按实际偏移生成，如 UTC+05:30
+		offset = *offsetSeconds
+		timezoneName = fixedZoneName(offset)
 	} else if timezoneName != "" {
 		// 查找映射的时区名称
 		if tz, ok := SUPPORTED_TIMEZONES[timezoneName]; ok {
@@ -72,13 +84,7 @@ func getTimezoneInfo(timezoneName string, tzOffset *int) (*model.TimezoneInfo, e
 	// 获取描述
 	description := TIMEZONE_DESCRIPTIONS[timezoneName]
 	if description == "" {
-		if offsetHours > 0 {
-			description = "东" + strconv.Itoa(offsetHours) + "区"
-		} else if offsetHours < 0 {
-			description = "西" + strconv.Itoa(-offsetHours) + "区"
-		} else {
-			description = "0区"
-		}
+		description = offsetDescription(offset)
 	}
 	
 	return &model.TimezoneInfo{
@@ -86,209 +92,26 @@ func getTimezoneInfo(timezoneName string, tzOffset *int) (*model.TimezoneInfo, e
 		OffsetHours:   offsetHours,
 		OffsetMinutes: offsetMinutes,
 		OffsetSeconds: offset,
+		Offset:        formatUTCOffset(offset),
 		Description:   description,
 	}, nil
 }
 
-// 将Python风格的时间格式转换为Go风格的格式
-func convertPythonFormatToGo(pythonFormat string) string {
-	// 如果是空格式，返回默认格式
-	if pythonFormat == "" {
-		return "2006-01-02 15:04:05"
-	}
-	
-	// 处理直接对应的Python到Go格式映射
-	result := pythonFormat
-	
-	// 检查特殊情况，整个格式是否为特定格式
-	if pythonFormat == "%c" {
-		return "Mon Jan 2 15:04:05 2006" // ANSIC 格式
-	} else if pythonFormat == "%x" {
-		return "01/02/06" // 日期格式
-	} else if pythonFormat == "%X" {
-		return "15:04:05" // 时间格式
-	}
-	
-	// 包含特殊处理的格式
-	// 这些指令需要特殊处理，不能直接映射到Go时间格式
-	var specialDirectives = []string{"%U", "%W", "%w", "%j", "%V", "%u"}
-	for _, directive := range specialDirectives {
-		if strings.Contains(pythonFormat, directive) {
-			// 这些特殊格式需要自定义处理，不使用直接替换
-			return pythonFormat
-		}
+// 相对时间需要参考时间等选项，仅时间转换和当前时间接口支持，其他接口使用时返回错误
+func checkOutputFormat(format string) error {
+	if format == "relative" {
+		return fmt.Errorf("该接口不支持relative输出格式，请使用时间转换接口")
 	}
-	
-	// 进行常规替换
-	// Python格式到Go格式的映射表
-	formatMap := map[string]string{
-		"%Y": "2006",     // 年份（4位数字）
-		"%y": "06",       // 年份（2位数字）
-		"%m": "01",       // 月份（01-12）
-		"%d": "02",       // 日期（01-31）
-		"%H": "15",       // 小时（24小时制，00-23）
-		"%I": "03",       // 小时（12小时制，01-12）
-		"%M": "04",       // 分钟（00-59）
-		"%S": "05",       // 秒（00-59）
-		"%f": "000000",   // 微秒
-		"%a": "Mon",      // 星期几缩写
-		"%A": "Monday",   // 星期几全称
-		"%b": "Jan",      // 月份缩写
-		"%B": "January",  // 月份全称
-		"%p": "PM",       // AM/PM
-		"%z": "-0700",    // UTC偏移量
-		"%Z": "MST",      // 时区名称
-		"%%": "%",        // 百分号
-		// 不添加 %V、%u、%w、%j、%U、%W 因为它们需要特殊处理
-	}
-	
-	// 使用替换处理所有格式代码
-	for pyFmt, goFmt := range formatMap {
-		result = strings.Replace(result, pyFmt, goFmt, -1)
-	}
-	
-	return result
-}
-
-// 格式化指定日期时间（处理包含特殊Python格式的情况）
-func formatWithPythonFormat(t time.Time, pythonFormat string) string {
-	// 获取1月1日，用于计算周数
-	jan1 := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
-	jan1Weekday := int(jan1.Weekday()) // Sunday=0
-	
-	// 特殊格式直接处理
-	if pythonFormat == "%c" {
-		return t.Format("Mon Jan 2 15:04:05 2006")
-	} else if pythonFormat == "%x" {
-		return t.Format("01/02/06")
-	} else if pythonFormat == "%X" {
-		return t.Format("15:04:05")
-	}
-	
-	// 识别格式字符串中的所有Python指令
-	var directives = []string{
-		"%Y", "%y", "%m", "%B", "%b", "%d", "%j", "%U", "%W", "%V", 
-		"%A", "%a", "%w", "%u", "%H", "%I", "%p", "%M", "%S", "%f",
-		"%z", "%Z", "%c", "%x", "%X", "%%",
-	}
-	
-	// 特殊计算函数：计算周数
-	weekNumber := func(doy, jan1Weekday int, firstDay int) int {
-		// firstDay: 0=Sunday, 1=Monday
-		offset := (7 - ((jan1Weekday - firstDay + 7) % 7)) % 7
-		if doy-1 < offset {
-			return 0
-		}
-		return (doy-1-offset)/7 + 1
-	}
-	
-	// 检查是否需要特殊处理
-	needsSpecialHandling := false
-	for _, directive := range []string{"%U", "%W", "%w", "%j", "%V", "%u"} {
-		if strings.Contains(pythonFormat, directive) {
-			needsSpecialHandling = true
-			break
-		}
-	}
-	
-	// 如果不需要特殊处理，使用常规转换
-	if !needsSpecialHandling {
-		goFormat := convertPythonFormatToGo(pythonFormat)
-		return t.Format(goFormat)
-	}
-	
-	// 需要特殊处理，逐个替换指令
-	result := pythonFormat
-	
-	// 依次处理每个指令
-	for _, directive := range directives {
-		// 如果格式中不包含该指令，跳过
-		if !strings.Contains(result, directive) {
-			continue
-		}
-		
-		var replacement string
-		
-		switch directive {
-		case "%Y":
-			replacement = fmt.Sprintf("%04d", t.Year())
-		case "%y":
-			replacement = fmt.Sprintf("%02d", t.Year()%100)
-		case "%m":
-			replacement = fmt.Sprintf("%02d", int(t.Month()))
-		case "%B":
-			replacement = t.Month().String()
-		case "%b":
-			replacement = t.Month().String()[:3]
-		case "%d":
-			replacement = fmt.Sprintf("%02d", t.Day())
-		case "%j":
-			replacement = fmt.Sprintf("%03d", t.YearDay())
-		case "%U": // 以周日为一周的第一天
-			week := weekNumber(t.YearDay(), jan1Weekday, 0)
-			replacement = fmt.Sprintf("%02d", week)
-		case "%W": // 以周一为一周的第一天
-			week := weekNumber(t.YearDay(), jan1Weekday, 1)
-			replacement = fmt.Sprintf("%02d", week)
-		case "%V": // ISO标准周数
-			_, isoWeek := t.ISOWeek()
-			replacement = fmt.Sprintf("%02d", isoWeek)
-		case "%A":
-			replacement = t.Weekday().String()
-		case "%a":
-			replacement = t.Weekday().String()[:3]
-		case "%w":
-			replacement = fmt.Sprintf("%d", int(t.Weekday()))
-		case "%u": // ISO周几（1-7，周一到周日）
-			weekday := int(t.Weekday())
-			if weekday == 0 { // 周日
-				weekday = 7
-			}
-			replacement = fmt.Sprintf("%d", weekday)
-		case "%H":
-			replacement = fmt.Sprintf("%02d", t.Hour())
-		case "%I":
-			hour12 := t.Hour() % 12
-			if hour12 == 0 {
-				hour12 = 12
-			}
-			replacement = fmt.Sprintf("%02d", hour12)
-		case "%p":
-			if t.Hour() < 12 {
-				replacement = "AM"
-			} else {
-				replacement = "PM"
-			}
-		case "%M":
-			replacement = fmt.Sprintf("%02d", t.Minute())
-		case "%S":
-			replacement = fmt.Sprintf("%02d", t.Second())
-		case "%f":
-			micro := t.Nanosecond() / 1000
-			replacement = fmt.Sprintf("%06d", micro)
-		case "%z":
-			replacement = t.Format("-0700")
-		case "%Z":
-			replacement = t.Format("MST")
-		case "%c":
-			replacement = t.Format(time.ANSIC)
-		case "%x":
-			replacement = t.Format("01/02/06")
-		case "%X":
-			replacement = t.Format("15:04:05")
-		case "%%":
-			replacement = "%"
-		}
-		
-		// 替换指令
-		result = strings.Replace(result, directive, replacement, -1)
-	}
-	
-	return result
+	return nil
 }
 
 // 格式化时间
 func formatTime(t time.Time, format string, customFormat string) string {
+	return formatTimeInLocale(t, format, customFormat, nil)
+}
+
+// 按语言区域格式化时间，locale为nil时月份、星期按英文输出，human为默认中文格式
+func formatTimeInLocale(t time.Time, format string, customFormat string, locale *formatLocale) string {
 	switch format {
 	case "date_only":
 		return t.Format("2006-01-02")
@@ -301,6 +124,9 @@ func formatTime(t time.Time, format string, customFormat string) string {
 	case "rfc3339":
 		return t.Format(time.RFC3339)
 	case "human":
+		if locale != nil {
+			return formatWithPythonFormat(t, locale.human, locale)
+		}
 		return t.Format("2006年01月02日 15时04分05秒")
 	case "timestamp":
 		return strconv.FormatInt(t.Unix(), 10)
@@ -309,7 +135,7 @@ func formatTime(t time.Time, format string, customFormat string) string {
 	case "custom":
 		if customFormat != "" {
 			// 使用改进的格式处理函数
-			return formatWithPythonFormat(t, customFormat)
+			return formatWithPythonFormat(t, customFormat, locale)
 		}
 		return t.Format("2006-01-02 15:04:05")
 	default:
@@ -318,22 +144,22 @@ func formatTime(t time.Time, format string, customFormat string) string {
 }
 
 // 获取当前时间
-func GetCurrentTime(format, timezone string, tzOffset *int, customFormat string) (*model.CurrentTimeResponse, error) {
-	// 获取时区信息
-	timezoneInfo, err := getTimezoneInfo(timezone, tzOffset)
+func GetCurrentTime(format, timezone string, tzOffset interface{}, customFormat, formatStyle, locale string, relative model.RelativeTimeOptions) (*model.CurrentTimeResponse, error) {
+	// 获取时区信息并加载时区
+	loc, timezoneInfo, err := resolveLocation(timezone, tzOffset)
 	if err != nil {
-		return nil, &model.ErrorResponse{Code: 9000, Message: "获取时区信息失败: " + err.Error()}
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
 	}
 	
-	// 加载时区
-	var loc *time.Location
-	if tzOffset != nil {
-		loc = time.FixedZone("FixedZone", *tzOffset*3600)
-	} else {
-		loc, err = time.LoadLocation(timezoneInfo.Name)
-		if err != nil {
-			return nil, &model.ErrorResponse{Code: 9000, Message: "加载时区失败: " + err.Error()}
-		}
+	// 月份、星期等名称的语言区域
+	nameLocale, err := resolveFormatLocale(locale)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+	
+	// 自定义格式风格
+	if _, err := resolveFormatStyle(formatStyle); err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
 	}
 	
 	// 获取当前时间
@@ -351,11 +177,21 @@ func GetCurrentTime(format, timezone string, tzOffset *int, customFormat string)
 	var formattedTime string
 	
 	if format == "custom" && customFormat != "" {
-		// 使用新的格式处理函数
-		formattedTime = formatWithPythonFormat(now, customFormat)
+		// 按format_style解析自定义格式
+		formattedTime, err = formatWithStyle(now, customFormat, formatStyle, nameLocale)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+		}
+	} else if format == "relative" {
+		// 相对时间（相对参考时间）
+		f, err := newRelativeFormatter(relative, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+		}
+		formattedTime = f.format(now)
 	} else {
 		// 非自定义格式
-		formattedTime = formatTime(now, format, customFormat)
+		formattedTime = formatTimeInLocale(now, format, customFormat, nameLocale)
 	}
 	
 	// 添加额外的响应字段
@@ -379,7 +215,7 @@ func GetCurrentTime(format, timezone string, tzOffset *int, customFormat string)
 }
 
 // 检查是否为周末
-func CheckIsWeekend(dateStr string) (*model.IsWeekendResponse, error) {
+func CheckIsWeekend(dateStr string, opts model.WorkdayOptions) (*model.IsWeekendResponse, error) {
 	var date time.Time
 	var err error
 	
@@ -402,11 +238,22 @@ func CheckIsWeekend(dateStr string) (*model.IsWeekendResponse, error) {
 	weekday := date.Weekday()
 	isWeekend := utils.IsWeekend(date)
 	
+	// 结合节假日日历判断是否休息
+	rule, err := newWorkdayRule(opts)
+	if err != nil {
+		return nil, workdayRuleError(err, 3001)
+	}
+	day := rule.classify(date)
+	
 	return &model.IsWeekendResponse{
 		Date:        date.Format("2006-01-02"),
 		IsWeekend:   isWeekend,
 		Weekday:     int(weekday),
 		WeekdayName: utils.WeekdayNames[weekday],
+		IsRestDay:   day.IsRest,
+		RestType:    day.RestType,
+		Reason:      day.Reason,
+		CalendarID:  opts.CalendarID,
 	}, nil
 }
 
@@ -526,27 +373,16 @@ func GetWeekNumber(dateStr string, inMonth bool, startWithMonday bool) (*model.W
 }
 
 // 获取时区信息
-func GetTimezoneInfo(timezone string, tzOffset *int) (*model.TimezoneInfoResponse, error) {
-	// 获取时区信息
-	timezoneInfo, err := getTimezoneInfo(timezone, tzOffset)
+func GetTimezoneInfo(timezone string, tzOffset interface{}) (*model.TimezoneInfoResponse, error) {
+	// 获取时区信息并加载时区
+	loc, timezoneInfo, err := resolveLocation(timezone, tzOffset)
 	if err != nil {
-		return nil, &model.ErrorResponse{Code: 9000, Message: "获取时区信息失败: " + err.Error()}
-	}
-	
-	// 获取当前时间
-	var loc *time.Location
-	if tzOffset != nil {
-		loc = time.FixedZone("FixedZone", *tzOffset*3600)
-	} else {
-		loc, err = time.LoadLocation(timezoneInfo.Name)
-		if err != nil {
-			return nil, &model.ErrorResponse{Code: 9000, Message: "加载时区失败: " + err.Error()}
-		}
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
 	}
 	
 	now := time.Now().In(loc)
 	
-	// 可用的时区偏移
+	// 可用的时区偏移（整小时）
 	availableOffsets := []int{-12, -11, -10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
 	
 	return &model.TimezoneInfoResponse{
@@ -554,50 +390,93 @@ func GetTimezoneInfo(timezone string, tzOffset *int) (*model.TimezoneInfoRespons
 		TimezoneInfo:     *timezoneInfo,
 		CurrentTime:      now.Format("2006-01-02 15:04:05"),
 		AvailableOffsets: availableOffsets,
+		SupportedOffsets: currentUTCOffsets(),
 	}, nil
 }
 
-// 时间格式转换
-func ConvertTime(req model.TimeConvertRequest) (*model.TimeConvertResponse, error) {
-	var inputTime time.Time
-	var err error
-	var originalStr string
-	
-	// 解析输入时间
-	switch v := req.TimeInput.(type) {
+// 解析时间输入：字符串按常见格式在指定时区解析，数字按秒或毫秒时间戳处理
+func parseTimeInput(input interface{}, loc *time.Location) (time.Time, string, error) {
+	switch v := input.(type) {
 	case string:
 		// 字符串输入，尝试解析
-		originalStr = v
-		inputTime, err = utils.ParseDateTime(v)
+		t, err := utils.ParseDateTimeInLocation(v, loc)
 		if err != nil {
-			return nil, &model.ErrorResponse{Code: 9000, Message: "时间格式解析失败: " + err.Error()}
+			return time.Time{}, v, fmt.Errorf("时间格式解析失败: %s", err.Error())
 		}
+		return t, v, nil
 	case float64:
 		// 数字输入，当作时间戳处理
-		originalStr = strconv.FormatInt(int64(v), 10)
-		if v > 9999999999 {
-			// 毫秒时间戳
-			inputTime = time.Unix(0, int64(v)*1000000)
-		} else {
-			// 秒时间戳
-			inputTime = time.Unix(int64(v), 0)
-		}
+		return unixTimestamp(int64(v)), strconv.FormatInt(int64(v), 10), nil
 	case int:
-		originalStr = strconv.Itoa(v)
-		if v > 9999999999 {
-			inputTime = time.Unix(0, int64(v)*1000000)
-		} else {
-			inputTime = time.Unix(int64(v), 0)
-		}
+		return unixTimestamp(int64(v)), strconv.Itoa(v), nil
 	case int64:
-		originalStr = strconv.FormatInt(v, 10)
-		if v > 9999999999 {
-			inputTime = time.Unix(0, v*1000000)
-		} else {
-			inputTime = time.Unix(v, 0)
-		}
+		return unixTimestamp(v), strconv.FormatInt(v, 10), nil
 	default:
-		return nil, &model.ErrorResponse{Code: 9000, Message: "不支持的时间输入格式"}
+		return time.Time{}, "", fmt.Errorf("不支持的时间输入格式")
+	}
+}
+
+// 时间戳转换为时间，超过10位视为毫秒时间戳
+func unixTimestamp(v int64) time.Time {
+	if v > 9999999999 {
+		return time.Unix(0, v*1000000)
+	}
+	return time.Unix(v, 0)
+}
+
+// 解析时区参数，返回时区和时区信息
+func resolveLocation(timezone string, tzOffset interface{}) (*time.Location, *model.TimezoneInfo, error) {
+	timezoneInfo, err := getTimezoneInfo(timezone, tzOffset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取时区信息失败: %s", err.Error())
+	}
+	
+	// 指定偏移量时使用固定时区
+	if offset, _ := parseTzOffset(tzOffset); offset != nil {
+		return time.FixedZone(timezoneInfo.Name, *offset), timezoneInfo, nil
+	}
+	loc, err := time.LoadLocation(timezoneInfo.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("加载时区失败: %s", err.Error())
+	}
+	return loc, timezoneInfo, nil
+}
+
+// 解析输入时区，支持时区名称、别名及UTC偏移（如+05:30）
+func resolveInputLocation(timezone string) (*time.Location, error) {
+	loc, _, err := resolveLocation(timezone, nil)
+	if err == nil {
+		return loc, nil
+	}
+	if offset, offsetErr := parseUTCOffset(timezone); offsetErr == nil {
+		return time.FixedZone(fixedZoneName(offset), offset), nil
+	}
+	return nil, fmt.Errorf("输入时区无效: %s", timezone)
+}
+
+// 时间格式转换
+func ConvertTime(req model.TimeConvertRequest) (*model.TimeConvertResponse, error) {
+	// 解析输入时区（未指定时不带时区的字符串按UTC处理）
+	inputLoc := time.UTC
+	if req.InputTimezone != "" {
+		loc, err := resolveInputLocation(req.InputTimezone)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+		}
+		inputLoc = loc
+	}
+	
+	// 解析输入时间
+	var inputTime time.Time
+	var originalStr string
+	var err error
+	if req.InputFormat != "" {
+		inputTime, originalStr, err = parseTimeInputWithFormat(req.TimeInput, req.InputFormat, inputLoc)
+	} else {
+		inputTime, originalStr, err = parseTimeInput(req.TimeInput, inputLoc)
+	}
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
 	}
 	
 	// 设置默认输出格式
@@ -605,35 +484,45 @@ func ConvertTime(req model.TimeConvertRequest) (*model.TimeConvertResponse, erro
 		req.OutputFormat = "default"
 	}
 	
-	// 获取时区信息
-	timezoneInfo, err := getTimezoneInfo(req.Timezone, req.TzOffset)
+	// 获取时区信息并加载时区
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
 	if err != nil {
-		return nil, &model.ErrorResponse{Code: 9000, Message: "获取时区信息失败: " + err.Error()}
-	}
-	
-	// 加载时区
-	var loc *time.Location
-	if req.TzOffset != nil {
-		loc = time.FixedZone("FixedZone", *req.TzOffset*3600)
-	} else {
-		loc, err = time.LoadLocation(timezoneInfo.Name)
-		if err != nil {
-			return nil, &model.ErrorResponse{Code: 9000, Message: "加载时区失败: " + err.Error()}
-		}
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
 	}
 	
 	// 转换时区
 	inputTime = inputTime.In(loc)
 	
+	// 月份、星期等名称的语言区域
+	nameLocale, err := resolveFormatLocale(req.Locale)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+	
+	// 自定义格式风格
+	if _, err := resolveFormatStyle(req.FormatStyle); err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+	
 	// 格式化输出
 	var formattedTime string
 	
 	if req.OutputFormat == "custom" && req.CustomFormat != "" {
-		// 使用新的格式处理函数
-		formattedTime = formatWithPythonFormat(inputTime, req.CustomFormat)
+		// 按format_style解析自定义格式
+		formattedTime, err = formatWithStyle(inputTime, req.CustomFormat, req.FormatStyle, nameLocale)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+		}
+	} else if req.OutputFormat == "relative" {
+		// 相对时间，如 5分钟前、3 weeks ago
+		f, err := newRelativeFormatter(req.RelativeTimeOptions, loc)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+		}
+		formattedTime = f.format(inputTime)
 	} else {
 		// 非自定义格式
-		formattedTime = formatTime(inputTime, req.OutputFormat, req.CustomFormat)
+		formattedTime = formatTimeInLocale(inputTime, req.OutputFormat, req.CustomFormat, nameLocale)
 	}
 	
 	return &model.TimeConvertResponse{
@@ -644,11 +533,74 @@ func ConvertTime(req model.TimeConvertRequest) (*model.TimeConvertResponse, erro
 	}, nil
 }
 
+// 批量时间转换，单项失败不影响其他项
+func BatchConvertTime(req model.BatchTimeConvertRequest) (*model.BatchTimeConvertResponse, error) {
+	if len(req.Items) > maxBatchConvertItems {
+		return nil, &model.ErrorResponse{Code: 4001, Message: fmt.Sprintf("items数量不能超过%d", maxBatchConvertItems)}
+	}
+	
+	// 公共参数
+	defaults := model.TimeConvertRequest{
+		InputFormat:         req.InputFormat,
+		InputTimezone:       req.InputTimezone,
+		OutputFormat:        req.OutputFormat,
+		Timezone:            req.Timezone,
+		TzOffset:            req.TzOffset,
+		CustomFormat:        req.CustomFormat,
+		FormatStyle:         req.FormatStyle,
+		Locale:              req.Locale,
+		RelativeTimeOptions: req.RelativeTimeOptions,
+	}
+	
+	response := &model.BatchTimeConvertResponse{
+		Total:   len(req.Items),
+		Results: make([]model.BatchTimeConvertItem, 0, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		itemReq := defaults
+		result := model.BatchTimeConvertItem{Index: i}
+		if fields, ok := item.(map[string]interface{}); ok {
+			// 完整请求对象：在公共参数基础上覆盖指定的字段
+			data, _ := json.Marshal(fields)
+			if err := json.Unmarshal(data, &itemReq); err != nil {
+				result.Error = &model.ErrorResponse{Code: 4001, Message: "参数验证错误: " + err.Error()}
+			}
+		} else {
+			itemReq.TimeInput = item
+		}
+		
+		if result.Error == nil && itemReq.TimeInput == nil {
+			result.Error = &model.ErrorResponse{Code: 4001, Message: "time_input不能为空"}
+		}
+		if result.Error == nil {
+			converted, err := ConvertTime(itemReq)
+			if err == nil {
+				result.Success = true
+				result.Result = converted
+			} else if e, ok := err.(*model.ErrorResponse); ok {
+				result.Error = e
+			} else {
+				result.Error = &model.ErrorResponse{Code: 9000, Message: err.Error()}
+			}
+		}
+		
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+	
+	return response, nil
+}
+
 // 工作日计算
 func CalculateWorkdays(req model.WorkdayRangeRequest) (*model.WorkdayRangeResponse, error) {
-	// 默认休息日模式: 周六日休息
-	if req.RestDayPattern == "" {
-		req.RestDayPattern = "0000011" // 周一到周五工作，周六日休息
+	// 创建工作日判定规则（默认周六日休息）
+	rule, err := newWorkdayRule(req.WorkdayOptions)
+	if err != nil {
+		return nil, workdayRuleError(err, 9000)
 	}
 	
 	// 验证日期
@@ -666,56 +618,29 @@ func CalculateWorkdays(req model.WorkdayRangeRequest) (*model.WorkdayRangeRespon
 		return nil, &model.ErrorResponse{Code: 9000, Message: "结束日期不能早于开始日期"}
 	}
 	
-	// 创建排除日期集合
-	excludeDates := make(map[string]bool)
-	for _, dateStr := range req.ExcludeDates {
-		excludeDates[dateStr] = true
-	}
-	
-	// 创建额外工作日集合
-	addDates := make(map[string]bool)
-	for _, dateStr := range req.AddDates {
-		addDates[dateStr] = true
-	}
-	
 	// 计算总天数
 	totalDays := int(endDate.Sub(startDate).Hours()/24) + 1
 	
 	// 遍历日期范围，统计工作日
 	var workdayList []string
 	var restdayList []string
+	restdayDetails := []model.RestdayDetail{}
 	
 	current := startDate
 	for i := 0; i < totalDays; i++ {
 		dateStr := current.Format("2006-01-02")
 		
-		// 判断是否为工作日
-		weekday := int(current.Weekday())
-		if weekday == 0 {
-			weekday = 7 // 调整周日为7
-		}
-		weekday-- // 调整为0-6的索引
-		
-		// 检查是否为休息日
-		isRestDay := false
-		if req.RestDayPattern[weekday] == '1' {
-			isRestDay = true
-		}
-		
-		// 应用自定义规则
-		if excludeDates[dateStr] {
-			// 排除日期，标记为休息日
-			isRestDay = true
-		}
-		
-		if addDates[dateStr] {
-			// 添加日期，标记为工作日
-			isRestDay = false
-		}
+		// 判断是否为休息日
+		day := rule.classify(current)
 		
 		// 添加到相应列表
-		if isRestDay {
+		if day.IsRest {
 			restdayList = append(restdayList, dateStr)
+			restdayDetails = append(restdayDetails, model.RestdayDetail{
+				Date:   dateStr,
+				Type:   day.RestType,
+				Reason: day.Reason,
+			})
 		} else {
 			workdayList = append(workdayList, dateStr)
 		}
@@ -724,11 +649,127 @@ func CalculateWorkdays(req model.WorkdayRangeRequest) (*model.WorkdayRangeRespon
 		current = current.AddDate(0, 0, 1)
 	}
 	
-	return &model.WorkdayRangeResponse{
-		TotalDays:   totalDays,
-		Workdays:    len(workdayList),
-		Restdays:    len(restdayList),
-		WorkdayList: workdayList,
-		RestdayList: restdayList,
-	}, nil
-} 
\ No newline at end of file
+	response := &model.WorkdayRangeResponse{
+		TotalDays:      totalDays,
+		Workdays:       len(workdayList),
+		Restdays:       len(restdayList),
+		WorkdayList:    workdayList,
+		RestdayList:    restdayList,
+		RestdayDetails: restdayDetails,
+		Calendar:       rule.calendar,
+		CalendarID:     req.CalendarID,
+	}
+	
+	// 使用节假日日历时返回数据版本和未覆盖的年份
+	if rule.calendar == CALENDAR_CN {
+		response.CalendarVersion = CN_HOLIDAY_DATA_VERSION
+		response.CalendarMissingYears = rule.missingYears(startDate, endDate)
+	}
+	
+	return response, nil
+}
+
+// 工作日偏移计算：从开始日期起向后（days>0）或向前（days<0）数第N个工作日，开始日期本身不计入
+// days为0时，开始日期为工作日则返回开始日期，否则顺延到下一个工作日
+func OffsetWorkdays(req model.WorkdayOffsetRequest) (*model.WorkdayOffsetResponse, error) {
+	rule, err := newWorkdayRule(req.WorkdayOptions)
+	if err != nil {
+		return nil, workdayRuleError(err, 3005)
+	}
+	
+	// 验证日期
+	if req.StartDate == "" {
+		req.StartDate = time.Now().Format("2006-01-02")
+	}
+	startDate, err := utils.ParseDate(req.StartDate)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3005, Message: "开始日期格式错误: " + err.Error()}
+	}
+	
+	if req.Days > maxWorkdayOffset || req.Days < -maxWorkdayOffset {
+		return nil, &model.ErrorResponse{Code: 3005, Message: fmt.Sprintf("偏移天数超出范围，最大支持±%d个工作日", maxWorkdayOffset)}
+	}
+	
+	// 确定步进方向和需要数的工作日个数
+	step := 1
+	remaining := req.Days
+	if req.Days < 0 {
+		step = -1
+		remaining = -req.Days
+	}
+	
+	skipped := []model.RestdayDetail{}
+	current := startDate
+	consecutiveRest := 0
+	
+	if req.Days == 0 {
+		// 开始日期为休息日时顺延
+		remaining = 1
+		current = current.AddDate(0, 0, -1)
+	}
+	
+	for remaining > 0 {
+		current = current.AddDate(0, 0, step)
+		day := rule.classify(current)
+		if day.IsRest {
+			skipped = append(skipped, model.RestdayDetail{
+				Date:   current.Format("2006-01-02"),
+				Type:   day.RestType,
+				Reason: day.Reason,
+			})
+			
+			// 防止休息日规则导致无法找到工作日
+			consecutiveRest++
+			if consecutiveRest > 366 {
+				return nil, &model.ErrorResponse{Code: 3005, Message: "连续366天没有工作日，请检查休息日规则"}
+			}
+			continue
+		}
+		consecutiveRest = 0
+		remaining--
+	}
+	
+	calendarDays := int(current.Sub(startDate).Hours() / 24)
+	
+	response := &model.WorkdayOffsetResponse{
+		StartDate:       startDate.Format("2006-01-02"),
+		Days:            req.Days,
+		ResultDate:      current.Format("2006-01-02"),
+		Weekday:         int(current.Weekday()),
+		WeekdayName:     utils.WeekdayNames[current.Weekday()],
+		CalendarDays:    calendarDays,
+		SkippedRestdays: skipped,
+		Calendar:        rule.calendar,
+		CalendarID:      req.CalendarID,
+	}
+	
+	// 使用节假日日历时返回数据版本和未覆盖的年份
+	if rule.calendar == CALENDAR_CN {
+		response.CalendarVersion = CN_HOLIDAY_DATA_VERSION
+		if current.Before(startDate) {
+			response.CalendarMissingYears = rule.missingYears(current, startDate)
+		} else {
+			response.CalendarMissingYears = rule.missingYears(startDate, current)
+		}
+	}
+	
+	return response, nil
+}
+
+// 获取下一个工作日（不含当天）
+func NextWorkday(req model.AdjacentWorkdayRequest) (*model.WorkdayOffsetResponse, error) {
+	return OffsetWorkdays(model.WorkdayOffsetRequest{
+		StartDate:      req.Date,
+		Days:           1,
+		WorkdayOptions: req.WorkdayOptions,
+	})
+}
+
+// 获取上一个工作日（不含当天）
+func PreviousWorkday(req model.AdjacentWorkdayRequest) (*model.WorkdayOffsetResponse, error) {
+	return OffsetWorkdays(model.WorkdayOffsetRequest{
+		StartDate:      req.Date,
+		Days:           -1,
+		WorkdayOptions: req.WorkdayOptions,
+	})
+}
@@ -0,0 +1,689 @@
+package service
+
+// IANA时区数据库版本（zone.tab）
+const IANA_TZDATA_VERSION = "2025b"
+
+// 国家/地区名称（ISO 3166）
+type timezoneCountry struct {
+	Name   string
+	NameZh string
+}
+
+var TIMEZONE_COUNTRIES = map[string]timezoneCountry{
+	"AD": {"Andorra", "安道尔"},
+	"AE": {"United Arab Emirates", "阿联酋"},
+	"AF": {"Afghanistan", "阿富汗"},
+	"AG": {"Antigua & Barbuda", "安提瓜和巴布达"},
+	"AI": {"Anguilla", "安圭拉"},
+	"AL": {"Albania", "阿尔巴尼亚"},
+	"AM": {"Armenia", "亚美尼亚"},
+	"AO": {"Angola", "安哥拉"},
+	"AQ": {"Antarctica", "南极洲"},
+	"AR": {"Argentina", "阿根廷"},
+	"AS": {"Samoa (American)", "美属萨摩亚"},
+	"AT": {"Austria", "奥地利"},
+	"AU": {"Australia", "澳大利亚"},
+	"AW": {"Aruba", "阿鲁巴"},
+	"AX": {"Åland Islands", "奥兰群岛"},
+	"AZ": {"Azerbaijan", "阿塞拜疆"},
+	"BA": {"Bosnia & Herzegovina", "波黑"},
+	"BB": {"Barbados", "巴巴多斯"},
+	"BD": {"Bangladesh", "孟加拉国"},
+	"BE": {"Belgium", "比利时"},
+	"BF": {"Burkina Faso", "布基纳法索"},
+	"BG": {"Bulgaria", "保加利亚"},
+	"BH": {"Bahrain", "巴林"},
+	"BI": {"Burundi", "布隆迪"},
+	"BJ": {"Benin", "贝宁"},
+	"BL": {"St Barthelemy", "圣巴泰勒米"},
+	"BM": {"Bermuda", "百慕大"},
+	"BN": {"Brunei", "文莱"},
+	"BO": {"Bolivia", "玻利维亚"},
+	"BQ": {"Caribbean NL", "荷兰加勒比区"},
+	"BR": {"Brazil", "巴西"},
+	"BS": {"Bahamas", "巴哈马"},
+	"BT": {"Bhutan", "不丹"},
+	"BW": {"Botswana", "博茨瓦纳"},
+	"BY": {"Belarus", "白俄罗斯"},
+	"BZ": {"Belize", "伯利兹"},
+	"CA": {"Canada", "加拿大"},
+	"CC": {"Cocos (Keeling) Islands", "科科斯（基林）群岛"},
+	"CD": {"Congo (Dem. Rep.)", "刚果（金）"},
+	"CF": {"Central African Rep.", "中非"},
+	"CG": {"Congo (Rep.)", "刚果（布）"},
+	"CH": {"Switzerland", "瑞士"},
+	"CI": {"Côte d'Ivoire", "科特迪瓦"},
+	"CK": {"Cook Islands", "库克群岛"},
+	"CL": {"Chile", "智利"},
+	"CM": {"Cameroon", "喀麦隆"},
+	"CN": {"China", "中国"},
+	"CO": {"Colombia", "哥伦比亚"},
+	"CR": {"Costa Rica", "哥斯达黎加"},
+	"CU": {"Cuba", "古巴"},
+	"CV": {"Cape Verde", "佛得角"},
+	"CW": {"Curaçao", "库拉索"},
+	"CX": {"Christmas Island", "圣诞岛"},
+	"CY": {"Cyprus", "塞浦路斯"},
+	"CZ": {"Czech Republic", "捷克"},
+	"DE": {"Germany", "德国"},
+	"DJ": {"Djibouti", "吉布提"},
+	"DK": {"Denmark", "丹麦"},
+	"DM": {"Dominica", "多米尼克"},
+	"DO": {"Dominican Republic", "多米尼加"},
+	"DZ": {"Algeria", "阿尔及利亚"},
+	"EC": {"Ecuador", "厄瓜多尔"},
+	"EE": {"Estonia", "爱沙尼亚"},
+	"EG": {"Egypt", "埃及"},
+	"EH": {"Western Sahara", "西撒哈拉"},
+	"ER": {"Eritrea", "厄立特里亚"},
+	"ES": {"Spain", "西班牙"},
+	"ET": {"Ethiopia", "埃塞俄比亚"},
+	"FI": {"Finland", "芬兰"},
+	"FJ": {"Fiji", "斐济"},
+	"FK": {"Falkland Islands", "福克兰群岛"},
+	"FM": {"Micronesia", "密克罗尼西亚"},
+	"FO": {"Faroe Islands", "法罗群岛"},
+	"FR": {"France", "法国"},
+	"GA": {"Gabon", "加蓬"},
+	"GB": {"Britain (UK)", "英国"},
+	"GD": {"Grenada", "格林纳达"},
+	"GE": {"Georgia", "格鲁吉亚"},
+	"GF": {"French Guiana", "法属圭亚那"},
+	"GG": {"Guernsey", "根西"},
+	"GH": {"Ghana", "加纳"},
+	"GI": {"Gibraltar", "直布罗陀"},
+	"GL": {"Greenland", "格陵兰"},
+	"GM": {"Gambia", "冈比亚"},
+	"GN": {"Guinea", "几内亚"},
+	"GP": {"Guadeloupe", "瓜德罗普"},
+	"GQ": {"Equatorial Guinea", "赤道几内亚"},
+	"GR": {"Greece", "希腊"},
+	"GS": {"South Georgia & the South Sandwich Islands", "南乔治亚和南桑威奇群岛"},
+	"GT": {"Guatemala", "危地马拉"},
+	"GU": {"Guam", "关岛"},
+	"GW": {"Guinea-Bissau", "几内亚比绍"},
+	"GY": {"Guyana", "圭亚那"},
+	"HK": {"Hong Kong", "中国香港"},
+	"HN": {"Honduras", "洪都拉斯"},
+	"HR": {"Croatia", "克罗地亚"},
+	"HT": {"Haiti", "海地"},
+	"HU": {"Hungary", "匈牙利"},
+	"ID": {"Indonesia", "印度尼西亚"},
+	"IE": {"Ireland", "爱尔兰"},
+	"IL": {"Israel", "以色列"},
+	"IM": {"Isle of Man", "马恩岛"},
+	"IN": {"India", "印度"},
+	"IO": {"British Indian Ocean Territory", "英属印度洋领地"},
+	"IQ": {"Iraq", "伊拉克"},
+	"IR": {"Iran", "伊朗"},
+	"IS": {"Iceland", "冰岛"},
+	"IT": {"Italy", "意大利"},
+	"JE": {"Jersey", "泽西"},
+	"JM": {"Jamaica", "牙买加"},
+	"JO": {"Jordan", "约旦"},
+	"JP": {"Japan", "日本"},
+	"KE": {"Kenya", "肯尼亚"},
+	"KG": {"Kyrgyzstan", "吉尔吉斯斯坦"},
+	"KH": {"Cambodia", "柬埔寨"},
+	"KI": {"Kiribati", "基里巴斯"},
+	"KM": {"Comoros", "科摩罗"},
+	"KN": {"St Kitts & Nevis", "圣基茨和尼维斯"},
+	"KP": {"Korea (North)", "朝鲜"},
+	"KR": {"Korea (South)", "韩国"},
+	"KW": {"Kuwait", "科威特"},
+	"KY": {"Cayman Islands", "开曼群岛"},
+	"KZ": {"Kazakhstan", "哈萨克斯坦"},
+	"LA": {"Laos", "老挝"},
+	"LB": {"Lebanon", "黎巴嫩"},
+	"LC": {"St Lucia", "圣卢西亚"},
+	"LI": {"Liechtenstein", "列支敦士登"},
+	"LK": {"Sri Lanka", "斯里兰卡"},
+	"LR": {"Liberia", "利比里亚"},
+	"LS": {"Lesotho", "莱索托"},
+	"LT": {"Lithuania", "立陶宛"},
+	"LU": {"Luxembourg", "卢森堡"},
+	"LV": {"Latvia", "拉脱维亚"},
+	"LY": {"Libya", "利比亚"},
+	"MA": {"Morocco", "摩洛哥"},
+	"MC": {"Monaco", "摩纳哥"},
+	"MD": {"Moldova", "摩尔多瓦"},
+	"ME": {"Montenegro", "黑山"},
+	"MF": {"St Martin (French)", "法属圣马丁"},
+	"MG": {"Madagascar", "马达加斯加"},
+	"MH": {"Marshall Islands", "马绍尔群岛"},
+	"MK": {"North Macedonia", "北马其顿"},
+	"ML": {"Mali", "马里"},
+	"MM": {"Myanmar (Burma)", "缅甸"},
+	"MN": {"Mongolia", "蒙古"},
+	"MO": {"Macau", "中国澳门"},
+	"MP": {"Northern Mariana Islands", "北马里亚纳群岛"},
+	"MQ": {"Martinique", "马提尼克"},
+	"MR": {"Mauritania", "毛里塔尼亚"},
+	"MS": {"Montserrat", "蒙特塞拉特"},
+	"MT": {"Malta", "马耳他"},
+	"MU": {"Mauritius", "毛里求斯"},
+	"MV": {"Maldives", "马尔代夫"},
+	"MW": {"Malawi", "马拉维"},
+	"MX": {"Mexico", "墨西哥"},
+	"MY": {"Malaysia", "马来西亚"},
+	"MZ": {"Mozambique", "莫桑比克"},
+	"NA": {"Namibia", "纳米比亚"},
+	"NC": {"New Caledonia", "新喀里多尼亚"},
+	"NE": {"Niger", "尼日尔"},
+	"NF": {"Norfolk Island", "诺福克岛"},
+	"NG": {"Nigeria", "尼日利亚"},
+	"NI": {"Nicaragua", "尼加拉瓜"},
+	"NL": {"Netherlands", "荷兰"},
+	"NO": {"Norway", "挪威"},
+	"NP": {"Nepal", "尼泊尔"},
+	"NR": {"Nauru", "瑙鲁"},
+	"NU": {"Niue", "纽埃"},
+	"NZ": {"New Zealand", "新西兰"},
+	"OM": {"Oman", "阿曼"},
+	"PA": {"Panama", "巴拿马"},
+	"PE": {"Peru", "秘鲁"},
+	"PF": {"French Polynesia", "法属波利尼西亚"},
+	"PG": {"Papua New Guinea", "巴布亚新几内亚"},
+	"PH": {"Philippines", "菲律宾"},
+	"PK": {"Pakistan", "巴基斯坦"},
+	"PL": {"Poland", "波兰"},
+	"PM": {"St Pierre & Miquelon", "圣皮埃尔和密克隆"},
+	"PN": {"Pitcairn", "皮特凯恩群岛"},
+	"PR": {"Puerto Rico", "波多黎各"},
+	"PS": {"Palestine", "巴勒斯坦"},
+	"PT": {"Portugal", "葡萄牙"},
+	"PW": {"Palau", "帕劳"},
+	"PY": {"Paraguay", "巴拉圭"},
+	"QA": {"Qatar", "卡塔尔"},
+	"RE": {"Réunion", "留尼汪"},
+	"RO": {"Romania", "罗马尼亚"},
+	"RS": {"Serbia", "塞尔维亚"},
+	"RU": {"Russia", "俄罗斯"},
+	"RW": {"Rwanda", "卢旺达"},
+	"SA": {"Saudi Arabia", "沙特阿拉伯"},
+	"SB": {"Solomon Islands", "所罗门群岛"},
+	"SC": {"Seychelles", "塞舌尔"},
+	"SD": {"Sudan", "苏丹"},
+	"SE": {"Sweden", "瑞典"},
+	"SG": {"Singapore", "新加坡"},
+	"SH": {"St Helena", "圣赫勒拿"},
+	"SI": {"Slovenia", "斯洛文尼亚"},
+	"SJ": {"Svalbard & Jan Mayen", "斯瓦尔巴和扬马延"},
+	"SK": {"Slovakia", "斯洛伐克"},
+	"SL": {"Sierra Leone", "塞拉利昂"},
+	"SM": {"San Marino", "圣马力诺"},
+	"SN": {"Senegal", "塞内加尔"},
+	"SO": {"Somalia", "索马里"},
+	"SR": {"Suriname", "苏里南"},
+	"SS": {"South Sudan", "南苏丹"},
+	"ST": {"Sao Tome & Principe", "圣多美和普林西比"},
+	"SV": {"El Salvador", "萨尔瓦多"},
+	"SX": {"St Maarten (Dutch)", "荷属圣马丁"},
+	"SY": {"Syria", "叙利亚"},
+	"SZ": {"Eswatini (Swaziland)", "斯威士兰"},
+	"TC": {"Turks & Caicos Is", "特克斯和凯科斯群岛"},
+	"TD": {"Chad", "乍得"},
+	"TF": {"French S. Terr.", "法属南部领地"},
+	"TG": {"Togo", "多哥"},
+	"TH": {"Thailand", "泰国"},
+	"TJ": {"Tajikistan", "塔吉克斯坦"},
+	"TK": {"Tokelau", "托克劳"},
+	"TL": {"East Timor", "东帝汶"},
+	"TM": {"Turkmenistan", "土库曼斯坦"},
+	"TN": {"Tunisia", "突尼斯"},
+	"TO": {"Tonga", "汤加"},
+	"TR": {"Turkey", "土耳其"},
+	"TT": {"Trinidad & Tobago", "特立尼达和多巴哥"},
+	"TV": {"Tuvalu", "图瓦卢"},
+	"TW": {"Taiwan", "中国台湾"},
+	"TZ": {"Tanzania", "坦桑尼亚"},
+	"UA": {"Ukraine", "乌克兰"},
+	"UG": {"Uganda", "乌干达"},
+	"UM": {"US minor outlying islands", "美国本土外小岛屿"},
+	"US": {"United States", "美国"},
+	"UY": {"Uruguay", "乌拉圭"},
+	"UZ": {"Uzbekistan", "乌兹别克斯坦"},
+	"VA": {"Vatican City", "梵蒂冈"},
+	"VC": {"St Vincent", "圣文森特和格林纳丁斯"},
+	"VE": {"Venezuela", "委内瑞拉"},
+	"VG": {"Virgin Islands (UK)", "英属维尔京群岛"},
+	"VI": {"Virgin Islands (US)", "美属维尔京群岛"},
+	"VN": {"Vietnam", "越南"},
+	"VU": {"Vanuatu", "瓦努阿图"},
+	"WF": {"Wallis & Futuna", "瓦利斯和富图纳"},
+	"WS": {"Samoa (western)", "萨摩亚"},
+	"YE": {"Yemen", "也门"},
+	"YT": {"Mayotte", "马约特"},
+	"ZA": {"South Africa", "南非"},
+	"ZM": {"Zambia", "赞比亚"},
+	"ZW": {"Zimbabwe", "津巴布韦"},
+}
+
+// IANA时区条目：时区名称、国家/地区代码、说明
+type timezoneEntry struct {
+	Name    string
+	Country string
+	Comment string
+}
+
+// IANA时区列表（按名称排序）
+var IANA_TIMEZONES = []timezoneEntry{
+	{"Africa/Abidjan", "CI", ""},
+	{"Africa/Accra", "GH", ""},
+	{"Africa/Addis_Ababa", "ET", ""},
+	{"Africa/Algiers", "DZ", ""},
+	{"Africa/Asmara", "ER", ""},
+	{"Africa/Bamako", "ML", ""},
+	{"Africa/Bangui", "CF", ""},
+	{"Africa/Banjul", "GM", ""},
+	{"Africa/Bissau", "GW", ""},
+	{"Africa/Blantyre", "MW", ""},
+	{"Africa/Brazzaville", "CG", ""},
+	{"Africa/Bujumbura", "BI", ""},
+	{"Africa/Cairo", "EG", ""},
+	{"Africa/Casablanca", "MA", ""},
+	{"Africa/Ceuta", "ES", "Ceuta, Melilla"},
+	{"Africa/Conakry", "GN", ""},
+	{"Africa/Dakar", "SN", ""},
+	{"Africa/Dar_es_Salaam", "TZ", ""},
+	{"Africa/Djibouti", "DJ", ""},
+	{"Africa/Douala", "CM", ""},
+	{"Africa/El_Aaiun", "EH", ""},
+	{"Africa/Freetown", "SL", ""},
+	{"Africa/Gaborone", "BW", ""},
+	{"Africa/Harare", "ZW", ""},
+	{"Africa/Johannesburg", "ZA", ""},
+	{"Africa/Juba", "SS", ""},
+	{"Africa/Kampala", "UG", ""},
+	{"Africa/Khartoum", "SD", ""},
+	{"Africa/Kigali", "RW", ""},
+	{"Africa/Kinshasa", "CD", "Dem. Rep. of Congo (west)"},
+	{"Africa/Lagos", "NG", ""},
+	{"Africa/Libreville", "GA", ""},
+	{"Africa/Lome", "TG", ""},
+	{"Africa/Luanda", "AO", ""},
+	{"Africa/Lubumbashi", "CD", "Dem. Rep. of Congo (east)"},
+	{"Africa/Lusaka", "ZM", ""},
+	{"Africa/Malabo", "GQ", ""},
+	{"Africa/Maputo", "MZ", ""},
+	{"Africa/Maseru", "LS", ""},
+	{"Africa/Mbabane", "SZ", ""},
+	{"Africa/Mogadishu", "SO", ""},
+	{"Africa/Monrovia", "LR", ""},
+	{"Africa/Nairobi", "KE", ""},
+	{"Africa/Ndjamena", "TD", ""},
+	{"Africa/Niamey", "NE", ""},
+	{"Africa/Nouakchott", "MR", ""},
+	{"Africa/Ouagadougou", "BF", ""},
+	{"Africa/Porto-Novo", "BJ", ""},
+	{"Africa/Sao_Tome", "ST", ""},
+	{"Africa/Tripoli", "LY", ""},
+	{"Africa/Tunis", "TN", ""},
+	{"Africa/Windhoek", "NA", ""},
+	{"America/Adak", "US", "Alaska - western Aleutians"},
+	{"America/Anchorage", "US", "Alaska (most areas)"},
+	{"America/Anguilla", "AI", ""},
+	{"America/Antigua", "AG", ""},
+	{"America/Araguaina", "BR", "Tocantins"},
+	{"America/Argentina/Buenos_Aires", "AR", "Buenos Aires (BA, CF)"},
+	{"America/Argentina/Catamarca", "AR", "Catamarca (CT), Chubut (CH)"},
+	{"America/Argentina/Cordoba", "AR", "Argentina (most areas: CB, CC, CN, ER, FM, MN, SE, SF)"},
+	{"America/Argentina/Jujuy", "AR", "Jujuy (JY)"},
+	{"America/Argentina/La_Rioja", "AR", "La Rioja (LR)"},
+	{"America/Argentina/Mendoza", "AR", "Mendoza (MZ)"},
+	{"America/Argentina/Rio_Gallegos", "AR", "Santa Cruz (SC)"},
+	{"America/Argentina/Salta", "AR", "Salta (SA, LP, NQ, RN)"},
+	{"America/Argentina/San_Juan", "AR", "San Juan (SJ)"},
+	{"America/Argentina/San_Luis", "AR", "San Luis (SL)"},
+	{"America/Argentina/Tucuman", "AR", "Tucuman (TM)"},
+	{"America/Argentina/Ushuaia", "AR", "Tierra del Fuego (TF)"},
+	{"America/Aruba", "AW", ""},
+	{"America/Asuncion", "PY", ""},
+	{"America/Atikokan", "CA", "EST - ON (Atikokan), NU (Coral H)"},
+	{"America/Bahia", "BR", "Bahia"},
+	{"America/Bahia_Banderas", "MX", "Bahia de Banderas"},
+	{"America/Barbados", "BB", ""},
+	{"America/Belem", "BR", "Para (east), Amapa"},
+	{"America/Belize", "BZ", ""},
+	{"America/Blanc-Sablon", "CA", "AST - QC (Lower North Shore)"},
+	{"America/Boa_Vista", "BR", "Roraima"},
+	{"America/Bogota", "CO", ""},
+	{"America/Boise", "US", "Mountain - ID (south), OR (east)"},
+	{"America/Cambridge_Bay", "CA", "Mountain - NU (west)"},
+	{"America/Campo_Grande", "BR", "Mato Grosso do Sul"},
+	{"America/Cancun", "MX", "Quintana Roo"},
+	{"America/Caracas", "VE", ""},
+	{"America/Cayenne", "GF", ""},
+	{"America/Cayman", "KY", ""},
+	{"America/Chicago", "US", "Central (most areas)"},
+	{"America/Chihuahua", "MX", "Chihuahua (most areas)"},
+	{"America/Ciudad_Juarez", "MX", "Chihuahua (US border - west)"},
+	{"America/Costa_Rica", "CR", ""},
+	{"America/Coyhaique", "CL", "Aysen Region"},
+	{"America/Creston", "CA", "MST - BC (Creston)"},
+	{"America/Cuiaba", "BR", "Mato Grosso"},
+	{"America/Curacao", "CW", ""},
+	{"America/Danmarkshavn", "GL", "National Park (east coast)"},
+	{"America/Dawson", "CA", "MST - Yukon (west)"},
+	{"America/Dawson_Creek", "CA", "MST - BC (Dawson Cr, Ft St John)"},
+	{"America/Denver", "US", "Mountain (most areas)"},
+	{"America/Detroit", "US", "Eastern - MI (most areas)"},
+	{"America/Dominica", "DM", ""},
+	{"America/Edmonton", "CA", "Mountain - AB, BC(E), NT(E), SK(W)"},
+	{"America/Eirunepe", "BR", "Amazonas (west)"},
+	{"America/El_Salvador", "SV", ""},
+	{"America/Fort_Nelson", "CA", "MST - BC (Ft Nelson)"},
+	{"America/Fortaleza", "BR", "Brazil (northeast: MA, PI, CE, RN, PB)"},
+	{"America/Glace_Bay", "CA", "Atlantic - NS (Cape Breton)"},
+	{"America/Goose_Bay", "CA", "Atlantic - Labrador (most areas)"},
+	{"America/Grand_Turk", "TC", ""},
+	{"America/Grenada", "GD", ""},
+	{"America/Guadeloupe", "GP", ""},
+	{"America/Guatemala", "GT", ""},
+	{"America/Guayaquil", "EC", "Ecuador (mainland)"},
+	{"America/Guyana", "GY", ""},
+	{"America/Halifax", "CA", "Atlantic - NS (most areas), PE"},
+	{"America/Havana", "CU", ""},
+	{"America/Hermosillo", "MX", "Sonora"},
+	{"America/Indiana/Indianapolis", "US", "Eastern - IN (most areas)"},
+	{"America/Indiana/Knox", "US", "Central - IN (Starke)"},
+	{"America/Indiana/Marengo", "US", "Eastern - IN (Crawford)"},
+	{"America/Indiana/Petersburg", "US", "Eastern - IN (Pike)"},
+	{"America/Indiana/Tell_City", "US", "Central - IN (Perry)"},
+	{"America/Indiana/Vevay", "US", "Eastern - IN (Switzerland)"},
+	{"America/Indiana/Vincennes", "US", "Eastern - IN (Da, Du, K, Mn)"},
+	{"America/Indiana/Winamac", "US", "Eastern - IN (Pulaski)"},
+	{"America/Inuvik", "CA", "Mountain - NT (west)"},
+	{"America/Iqaluit", "CA", "Eastern - NU (most areas)"},
+	{"America/Jamaica", "JM", ""},
+	{"America/Juneau", "US", "Alaska - Juneau area"},
+	{"America/Kentucky/Louisville", "US", "Eastern - KY (Louisville area)"},
+	{"America/Kentucky/Monticello", "US", "Eastern - KY (Wayne)"},
+	{"America/Kralendijk", "BQ", ""},
+	{"America/La_Paz", "BO", ""},
+	{"America/Lima", "PE", ""},
+	{"America/Los_Angeles", "US", "Pacific"},
+	{"America/Lower_Princes", "SX", ""},
+	{"America/Maceio", "BR", "Alagoas, Sergipe"},
+	{"America/Managua", "NI", ""},
+	{"America/Manaus", "BR", "Amazonas (east)"},
+	{"America/Marigot", "MF", ""},
+	{"America/Martinique", "MQ", ""},
+	{"America/Matamoros", "MX", "Coahuila, Nuevo Leon, Tamaulipas (US border)"},
+	{"America/Mazatlan", "MX", "Baja California Sur, Nayarit (most areas), Sinaloa"},
+	{"America/Menominee", "US", "Central - MI (Wisconsin border)"},
+	{"America/Merida", "MX", "Campeche, Yucatan"},
+	{"America/Metlakatla", "US", "Alaska - Annette Island"},
+	{"America/Mexico_City", "MX", "Central Mexico"},
+	{"America/Miquelon", "PM", ""},
+	{"America/Moncton", "CA", "Atlantic - New Brunswick"},
+	{"America/Monterrey", "MX", "Durango; Coahuila, Nuevo Leon, Tamaulipas (most areas)"},
+	{"America/Montevideo", "UY", ""},
+	{"America/Montserrat", "MS", ""},
+	{"America/Nassau", "BS", ""},
+	{"America/New_York", "US", "Eastern (most areas)"},
+	{"America/Nome", "US", "Alaska (west)"},
+	{"America/Noronha", "BR", "Atlantic islands"},
+	{"America/North_Dakota/Beulah", "US", "Central - ND (Mercer)"},
+	{"America/North_Dakota/Center", "US", "Central - ND (Oliver)"},
+	{"America/North_Dakota/New_Salem", "US", "Central - ND (Morton rural)"},
+	{"America/Nuuk", "GL", "most of Greenland"},
+	{"America/Ojinaga", "MX", "Chihuahua (US border - east)"},
+	{"America/Panama", "PA", ""},
+	{"America/Paramaribo", "SR", ""},
+	{"America/Phoenix", "US", "MST - AZ (except Navajo)"},
+	{"America/Port-au-Prince", "HT", ""},
+	{"America/Port_of_Spain", "TT", ""},
+	{"America/Porto_Velho", "BR", "Rondonia"},
+	{"America/Puerto_Rico", "PR", ""},
+	{"America/Punta_Arenas", "CL", "Magallanes Region"},
+	{"America/Rankin_Inlet", "CA", "Central - NU (central)"},
+	{"America/Recife", "BR", "Pernambuco"},
+	{"America/Regina", "CA", "CST - SK (most areas)"},
+	{"America/Resolute", "CA", "Central - NU (Resolute)"},
+	{"America/Rio_Branco", "BR", "Acre"},
+	{"America/Santarem", "BR", "Para (west)"},
+	{"America/Santiago", "CL", "most of Chile"},
+	{"America/Santo_Domingo", "DO", ""},
+	{"America/Sao_Paulo", "BR", "Brazil (southeast: GO, DF, MG, ES, RJ, SP, PR, SC, RS)"},
+	{"America/Scoresbysund", "GL", "Scoresbysund/Ittoqqortoormiit"},
+	{"America/Sitka", "US", "Alaska - Sitka area"},
+	{"America/St_Barthelemy", "BL", ""},
+	{"America/St_Johns", "CA", "Newfoundland, Labrador (SE)"},
+	{"America/St_Kitts", "KN", ""},
+	{"America/St_Lucia", "LC", ""},
+	{"America/St_Thomas", "VI", ""},
+	{"America/St_Vincent", "VC", ""},
+	{"America/Swift_Current", "CA", "CST - SK (midwest)"},
+	{"America/Tegucigalpa", "HN", ""},
+	{"America/Thule", "GL", "Thule/Pituffik"},
+	{"America/Tijuana", "MX", "Baja California"},
+	{"America/Toronto", "CA", "Eastern - ON & QC (most areas)"},
+	{"America/Tortola", "VG", ""},
+	{"America/Vancouver", "CA", "Pacific - BC (most areas)"},
+	{"America/Whitehorse", "CA", "MST - Yukon (east)"},
+	{"America/Winnipeg", "CA", "Central - ON (west), Manitoba"},
+	{"America/Yakutat", "US", "Alaska - Yakutat"},
+	{"Antarctica/Casey", "AQ", "Casey"},
+	{"Antarctica/Davis", "AQ", "Davis"},
+	{"Antarctica/DumontDUrville", "AQ", "Dumont-d'Urville"},
+	{"Antarctica/Macquarie", "AU", "Macquarie Island"},
+	{"Antarctica/Mawson", "AQ", "Mawson"},
+	{"Antarctica/McMurdo", "AQ", "New Zealand time - McMurdo, South Pole"},
+	{"Antarctica/Palmer", "AQ", "Palmer"},
+	{"Antarctica/Rothera", "AQ", "Rothera"},
+	{"Antarctica/Syowa", "AQ", "Syowa"},
+	{"Antarctica/Troll", "AQ", "Troll"},
+	{"Antarctica/Vostok", "AQ", "Vostok"},
+	{"Arctic/Longyearbyen", "SJ", ""},
+	{"Asia/Aden", "YE", ""},
+	{"Asia/Almaty", "KZ", "most of Kazakhstan"},
+	{"Asia/Amman", "JO", ""},
+	{"Asia/Anadyr", "RU", "MSK+09 - Bering Sea"},
+	{"Asia/Aqtau", "KZ", "Mangghystau/Mankistau"},
+	{"Asia/Aqtobe", "KZ", "Aqtobe/Aktobe"},
+	{"Asia/Ashgabat", "TM", ""},
+	{"Asia/Atyrau", "KZ", "Atyrau/Atirau/Gur'yev"},
+	{"Asia/Baghdad", "IQ", ""},
+	{"Asia/Bahrain", "BH", ""},
+	{"Asia/Baku", "AZ", ""},
+	{"Asia/Bangkok", "TH", ""},
+	{"Asia/Barnaul", "RU", "MSK+04 - Altai"},
+	{"Asia/Beirut", "LB", ""},
+	{"Asia/Bishkek", "KG", ""},
+	{"Asia/Brunei", "BN", ""},
+	{"Asia/Chita", "RU", "MSK+06 - Zabaykalsky"},
+	{"Asia/Colombo", "LK", ""},
+	{"Asia/Damascus", "SY", ""},
+	{"Asia/Dhaka", "BD", ""},
+	{"Asia/Dili", "TL", ""},
+	{"Asia/Dubai", "AE", ""},
+	{"Asia/Dushanbe", "TJ", ""},
+	{"Asia/Famagusta", "CY", "Northern Cyprus"},
+	{"Asia/Gaza", "PS", "Gaza Strip"},
+	{"Asia/Hebron", "PS", "West Bank"},
+	{"Asia/Ho_Chi_Minh", "VN", ""},
+	{"Asia/Hong_Kong", "HK", ""},
+	{"Asia/Hovd", "MN", "Bayan-Olgii, Hovd, Uvs"},
+	{"Asia/Irkutsk", "RU", "MSK+05 - Irkutsk, Buryatia"},
+	{"Asia/Jakarta", "ID", "Java, Sumatra"},
+	{"Asia/Jayapura", "ID", "New Guinea (West Papua / Irian Jaya), Malukus/Moluccas"},
+	{"Asia/Jerusalem", "IL", ""},
+	{"Asia/Kabul", "AF", ""},
+	{"Asia/Kamchatka", "RU", "MSK+09 - Kamchatka"},
+	{"Asia/Karachi", "PK", ""},
+	{"Asia/Kathmandu", "NP", ""},
+	{"Asia/Khandyga", "RU", "MSK+06 - Tomponsky, Ust-Maysky"},
+	{"Asia/Kolkata", "IN", ""},
+	{"Asia/Krasnoyarsk", "RU", "MSK+04 - Krasnoyarsk area"},
+	{"Asia/Kuala_Lumpur", "MY", "Malaysia (peninsula)"},
+	{"Asia/Kuching", "MY", "Sabah, Sarawak"},
+	{"Asia/Kuwait", "KW", ""},
+	{"Asia/Macau", "MO", ""},
+	{"Asia/Magadan", "RU", "MSK+08 - Magadan"},
+	{"Asia/Makassar", "ID", "Borneo (east, south), Sulawesi/Celebes, Bali, Nusa Tengarra, Timor (west)"},
+	{"Asia/Manila", "PH", ""},
+	{"Asia/Muscat", "OM", ""},
+	{"Asia/Nicosia", "CY", "most of Cyprus"},
+	{"Asia/Novokuznetsk", "RU", "MSK+04 - Kemerovo"},
+	{"Asia/Novosibirsk", "RU", "MSK+04 - Novosibirsk"},
+	{"Asia/Omsk", "RU", "MSK+03 - Omsk"},
+	{"Asia/Oral", "KZ", "West Kazakhstan"},
+	{"Asia/Phnom_Penh", "KH", ""},
+	{"Asia/Pontianak", "ID", "Borneo (west, central)"},
+	{"Asia/Pyongyang", "KP", ""},
+	{"Asia/Qatar", "QA", ""},
+	{"Asia/Qostanay", "KZ", "Qostanay/Kostanay/Kustanay"},
+	{"Asia/Qyzylorda", "KZ", "Qyzylorda/Kyzylorda/Kzyl-Orda"},
+	{"Asia/Riyadh", "SA", ""},
+	{"Asia/Sakhalin", "RU", "MSK+08 - Sakhalin Island"},
+	{"Asia/Samarkand", "UZ", "Uzbekistan (west)"},
+	{"Asia/Seoul", "KR", ""},
+	{"Asia/Shanghai", "CN", "Beijing Time"},
+	{"Asia/Singapore", "SG", ""},
+	{"Asia/Srednekolymsk", "RU", "MSK+08 - Sakha (E), N Kuril Is"},
+	{"Asia/Taipei", "TW", ""},
+	{"Asia/Tashkent", "UZ", "Uzbekistan (east)"},
+	{"Asia/Tbilisi", "GE", ""},
+	{"Asia/Tehran", "IR", ""},
+	{"Asia/Thimphu", "BT", ""},
+	{"Asia/Tokyo", "JP", ""},
+	{"Asia/Tomsk", "RU", "MSK+04 - Tomsk"},
+	{"Asia/Ulaanbaatar", "MN", "most of Mongolia"},
+	{"Asia/Urumqi", "CN", "Xinjiang Time"},
+	{"Asia/Ust-Nera", "RU", "MSK+07 - Oymyakonsky"},
+	{"Asia/Vientiane", "LA", ""},
+	{"Asia/Vladivostok", "RU", "MSK+07 - Amur River"},
+	{"Asia/Yakutsk", "RU", "MSK+06 - Lena River"},
+	{"Asia/Yangon", "MM", ""},
+	{"Asia/Yekaterinburg", "RU", "MSK+02 - Urals"},
+	{"Asia/Yerevan", "AM", ""},
+	{"Atlantic/Azores", "PT", "Azores"},
+	{"Atlantic/Bermuda", "BM", ""},
+	{"Atlantic/Canary", "ES", "Canary Islands"},
+	{"Atlantic/Cape_Verde", "CV", ""},
+	{"Atlantic/Faroe", "FO", ""},
+	{"Atlantic/Madeira", "PT", "Madeira Islands"},
+	{"Atlantic/Reykjavik", "IS", ""},
+	{"Atlantic/South_Georgia", "GS", ""},
+	{"Atlantic/St_Helena", "SH", ""},
+	{"Atlantic/Stanley", "FK", ""},
+	{"Australia/Adelaide", "AU", "South Australia"},
+	{"Australia/Brisbane", "AU", "Queensland (most areas)"},
+	{"Australia/Broken_Hill", "AU", "New South Wales (Yancowinna)"},
+	{"Australia/Darwin", "AU", "Northern Territory"},
+	{"Australia/Eucla", "AU", "Western Australia (Eucla)"},
+	{"Australia/Hobart", "AU", "Tasmania"},
+	{"Australia/Lindeman", "AU", "Queensland (Whitsunday Islands)"},
+	{"Australia/Lord_Howe", "AU", "Lord Howe Island"},
+	{"Australia/Melbourne", "AU", "Victoria"},
+	{"Australia/Perth", "AU", "Western Australia (most areas)"},
+	{"Australia/Sydney", "AU", "New South Wales (most areas)"},
+	{"Europe/Amsterdam", "NL", ""},
+	{"Europe/Andorra", "AD", ""},
+	{"Europe/Astrakhan", "RU", "MSK+01 - Astrakhan"},
+	{"Europe/Athens", "GR", ""},
+	{"Europe/Belgrade", "RS", ""},
+	{"Europe/Berlin", "DE", "most of Germany"},
+	{"Europe/Bratislava", "SK", ""},
+	{"Europe/Brussels", "BE", ""},
+	{"Europe/Bucharest", "RO", ""},
+	{"Europe/Budapest", "HU", ""},
+	{"Europe/Busingen", "DE", "Busingen"},
+	{"Europe/Chisinau", "MD", ""},
+	{"Europe/Copenhagen", "DK", ""},
+	{"Europe/Dublin", "IE", ""},
+	{"Europe/Gibraltar", "GI", ""},
+	{"Europe/Guernsey", "GG", ""},
+	{"Europe/Helsinki", "FI", ""},
+	{"Europe/Isle_of_Man", "IM", ""},
+	{"Europe/Istanbul", "TR", ""},
+	{"Europe/Jersey", "JE", ""},
+	{"Europe/Kaliningrad", "RU", "MSK-01 - Kaliningrad"},
+	{"Europe/Kirov", "RU", "MSK+00 - Kirov"},
+	{"Europe/Kyiv", "UA", "most of Ukraine"},
+	{"Europe/Lisbon", "PT", "Portugal (mainland)"},
+	{"Europe/Ljubljana", "SI", ""},
+	{"Europe/London", "GB", ""},
+	{"Europe/Luxembourg", "LU", ""},
+	{"Europe/Madrid", "ES", "Spain (mainland)"},
+	{"Europe/Malta", "MT", ""},
+	{"Europe/Mariehamn", "AX", ""},
+	{"Europe/Minsk", "BY", ""},
+	{"Europe/Monaco", "MC", ""},
+	{"Europe/Moscow", "RU", "MSK+00 - Moscow area"},
+	{"Europe/Oslo", "NO", ""},
+	{"Europe/Paris", "FR", ""},
+	{"Europe/Podgorica", "ME", ""},
+	{"Europe/Prague", "CZ", ""},
+	{"Europe/Riga", "LV", ""},
+	{"Europe/Rome", "IT", ""},
+	{"Europe/Samara", "RU", "MSK+01 - Samara, Udmurtia"},
+	{"Europe/San_Marino", "SM", ""},
+	{"Europe/Sarajevo", "BA", ""},
+	{"Europe/Saratov", "RU", "MSK+01 - Saratov"},
+	{"Europe/Simferopol", "UA", "Crimea"},
+	{"Europe/Skopje", "MK", ""},
+	{"Europe/Sofia", "BG", ""},
+	{"Europe/Stockholm", "SE", ""},
+	{"Europe/Tallinn", "EE", ""},
+	{"Europe/Tirane", "AL", ""},
+	{"Europe/Ulyanovsk", "RU", "MSK+01 - Ulyanovsk"},
+	{"Europe/Vaduz", "LI", ""},
+	{"Europe/Vatican", "VA", ""},
+	{"Europe/Vienna", "AT", ""},
+	{"Europe/Vilnius", "LT", ""},
+	{"Europe/Volgograd", "RU", "MSK+00 - Volgograd"},
+	{"Europe/Warsaw", "PL", ""},
+	{"Europe/Zagreb", "HR", ""},
+	{"Europe/Zurich", "CH", ""},
+	{"Indian/Antananarivo", "MG", ""},
+	{"Indian/Chagos", "IO", ""},
+	{"Indian/Christmas", "CX", ""},
+	{"Indian/Cocos", "CC", ""},
+	{"Indian/Comoro", "KM", ""},
+	{"Indian/Kerguelen", "TF", ""},
+	{"Indian/Mahe", "SC", ""},
+	{"Indian/Maldives", "MV", ""},
+	{"Indian/Mauritius", "MU", ""},
+	{"Indian/Mayotte", "YT", ""},
+	{"Indian/Reunion", "RE", ""},
+	{"Pacific/Apia", "WS", ""},
+	{"Pacific/Auckland", "NZ", "most of New Zealand"},
+	{"Pacific/Bougainville", "PG", "Bougainville"},
+	{"Pacific/Chatham", "NZ", "Chatham Islands"},
+	{"Pacific/Chuuk", "FM", "Chuuk/Truk, Yap"},
+	{"Pacific/Easter", "CL", "Easter Island"},
+	{"Pacific/Efate", "VU", ""},
+	{"Pacific/Fakaofo", "TK", ""},
+	{"Pacific/Fiji", "FJ", ""},
+	{"Pacific/Funafuti", "TV", ""},
+	{"Pacific/Galapagos", "EC", "Galapagos Islands"},
+	{"Pacific/Gambier", "PF", "Gambier Islands"},
+	{"Pacific/Guadalcanal", "SB", ""},
+	{"Pacific/Guam", "GU", ""},
+	{"Pacific/Honolulu", "US", "Hawaii"},
+	{"Pacific/Kanton", "KI", "Phoenix Islands"},
+	{"Pacific/Kiritimati", "KI", "Line Islands"},
+	{"Pacific/Kosrae", "FM", "Kosrae"},
+	{"Pacific/Kwajalein", "MH", "Kwajalein"},
+	{"Pacific/Majuro", "MH", "most of Marshall Islands"},
+	{"Pacific/Marquesas", "PF", "Marquesas Islands"},
+	{"Pacific/Midway", "UM", "Midway Islands"},
+	{"Pacific/Nauru", "NR", ""},
+	{"Pacific/Niue", "NU", ""},
+	{"Pacific/Norfolk", "NF", ""},
+	{"Pacific/Noumea", "NC", ""},
+	{"Pacific/Pago_Pago", "AS", ""},
+	{"Pacific/Palau", "PW", ""},
+	{"Pacific/Pitcairn", "PN", ""},
+	{"Pacific/Pohnpei", "FM", "Pohnpei/Ponape"},
+	{"Pacific/Port_Moresby", "PG", "most of Papua New Guinea"},
+	{"Pacific/Rarotonga", "CK", ""},
+	{"Pacific/Saipan", "MP", ""},
+	{"Pacific/Tahiti", "PF", "Society Islands"},
+	{"Pacific/Tarawa", "KI", "Gilbert Islands"},
+	{"Pacific/Tongatapu", "TO", ""},
+	{"Pacific/Wake", "UM", "Wake Island"},
+	{"Pacific/Wallis", "WF", ""},
+}
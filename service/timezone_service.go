@@ -0,0 +1,433 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 时区转换查询默认及最大返回数量
+const (
+	defaultTransitionCount = 3
+	maxTransitionCount     = 20
+)
+
+// UTC偏移格式，如 +08:00、-0330、UTC+8、GMT-3:30
+var utcOffsetPattern = regexp.MustCompile(`^(?i:UTC|GMT)?\s*([+-])?(\d{1,2})(?::?(\d{2}))?$`)
+
+// 纯数字偏移，如 8、5.5、-3.5、330、+0530
+var numericOffsetPattern = regexp.MustCompile(`^([+-])?(\d+)(\.\d+)?$`)
+
+// 解析UTC偏移字符串，支持 +05:30、+0530、UTC+8、小时数（可为小数，如 5.5、-3.5）及分钟数（如 330、-210），返回秒数
+func parseUTCOffset(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("时区偏移不能为空")
+	}
+	if strings.EqualFold(s, "UTC") || strings.EqualFold(s, "GMT") || s == "Z" {
+		return 0, nil
+	}
+
+	// 带符号或以0开头的4位数字按时分解析（如 +0530、0530），其余纯数字按小时数或分钟数解析
+	isHHMM := func(matches []string) bool {
+		return len(matches[2]) == 4 && matches[3] == "" && (matches[1] != "" || matches[2][0] == '0')
+	}
+
+	var seconds int
+	if matches := numericOffsetPattern.FindStringSubmatch(s); matches != nil && !isHHMM(matches) {
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("时区偏移格式错误: %s", s)
+		}
+		seconds, err = offsetNumberToSeconds(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", err.Error(), s)
+		}
+	} else if matches := utcOffsetPattern.FindStringSubmatch(s); matches != nil {
+		hours, _ := strconv.Atoi(matches[2])
+		minutes := 0
+		if matches[3] != "" {
+			minutes, _ = strconv.Atoi(matches[3])
+		}
+		if minutes > 59 {
+			return 0, fmt.Errorf("时区偏移格式错误: %s", s)
+		}
+		seconds = hours*3600 + minutes*60
+		if matches[1] == "-" {
+			seconds = -seconds
+		}
+	} else {
+		return 0, fmt.Errorf("时区偏移格式错误: %s，应为+05:30、小时数或分钟数", s)
+	}
+
+	if seconds < -12*3600 || seconds > 14*3600 {
+		return 0, fmt.Errorf("时区偏移超出范围(-12:00至+14:00): %s", s)
+	}
+	return seconds, nil
+}
+
+// 数字偏移转换为秒数，绝对值不超过14视为小时数（可为小数），否则视为分钟数
+func offsetNumberToSeconds(value float64) (int, error) {
+	if math.Abs(value) <= 14 {
+		return int(math.Round(value * 3600)), nil
+	}
+	if value != math.Trunc(value) {
+		return 0, fmt.Errorf("分钟偏移必须为整数")
+	}
+	// 实际使用的偏移均为15分钟的整数倍，530等数字多为漏写符号的时分，不按分钟解释
+	if int(value)%15 != 0 {
+		return 0, fmt.Errorf("分钟偏移必须为15的整数倍，时分格式请使用+05:30或+0530")
+	}
+	return int(value) * 60, nil
+}
+
+// 解析请求中的时区偏移参数，支持数字（小时数或分钟数）及字符串，未指定时返回nil
+func parseTzOffset(tzOffset interface{}) (*int, error) {
+	var seconds int
+	var err error
+	switch v := tzOffset.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		seconds, err = parseUTCOffset(v)
+	case float64:
+		seconds, err = offsetNumberToSeconds(v)
+	case int:
+		seconds, err = offsetNumberToSeconds(float64(v))
+	default:
+		return nil, fmt.Errorf("不支持的时区偏移类型: %T", tzOffset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if seconds < -12*3600 || seconds > 14*3600 {
+		return nil, fmt.Errorf("时区偏移超出范围(-12:00至+14:00): %v", tzOffset)
+	}
+	return &seconds, nil
+}
+
+// 固定偏移时区名称，如 UTC+08:00、UTC-03:30，零偏移为UTC
+func fixedZoneName(seconds int) string {
+	if seconds == 0 {
+		return "UTC"
+	}
+	return "UTC" + formatUTCOffset(seconds)
+}
+
+// 当前各IANA时区使用中的全部UTC偏移，按从小到大排列
+func currentUTCOffsets() []string {
+	now := time.Now()
+	seen := map[int]bool{}
+	offsets := []int{}
+	for _, entry := range IANA_TIMEZONES {
+		loc, err := time.LoadLocation(entry.Name)
+		if err != nil {
+			continue
+		}
+		_, offset := now.In(loc).Zone()
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+
+	result := make([]string, len(offsets))
+	for i, offset := range offsets {
+		result[i] = formatUTCOffset(offset)
+	}
+	return result
+}
+
+// 格式化UTC偏移，如 +08:00、-03:30
+func formatUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// 时区中文描述，如 东8区、西3:30区、东5:45区
+func offsetDescription(seconds int) string {
+	if seconds == 0 {
+		return "0区"
+	}
+	prefix := "东"
+	if seconds < 0 {
+		prefix = "西"
+		seconds = -seconds
+	}
+	hours, minutes := seconds/3600, (seconds%3600)/60
+	if minutes == 0 {
+		return prefix + strconv.Itoa(hours) + "区"
+	}
+	return fmt.Sprintf("%s%d:%02d区", prefix, hours, minutes)
+}
+
+// 时区名称对应的别名列表
+func timezoneAliases(name string) []string {
+	aliases := []string{}
+	for alias, tz := range SUPPORTED_TIMEZONES {
+		if tz == name {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// 时区在指定年份是否实行夏令时
+func observesDST(loc *time.Location, year int) bool {
+	t := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+	for t.Before(end) {
+		if t.IsDST() {
+			return true
+		}
+		_, next := t.ZoneBounds()
+		if next.IsZero() {
+			return false
+		}
+		t = next
+	}
+	return false
+}
+
+// 构建时区列表条目
+func buildTimezoneItem(entry timezoneEntry, now time.Time) (*model.TimezoneItem, error) {
+	loc, err := time.LoadLocation(entry.Name)
+	if err != nil {
+		return nil, err
+	}
+	local := now.In(loc)
+	abbreviation, offset := local.Zone()
+	country := TIMEZONE_COUNTRIES[entry.Country]
+
+	description := TIMEZONE_DESCRIPTIONS[entry.Name]
+	if description == "" {
+		description = offsetDescription(offset)
+	}
+
+	return &model.TimezoneItem{
+		Name:          entry.Name,
+		CountryCode:   entry.Country,
+		CountryName:   country.Name,
+		CountryNameZh: country.NameZh,
+		Comment:       entry.Comment,
+		Offset:        formatUTCOffset(offset),
+		OffsetSeconds: offset,
+		Description:   description,
+		Abbreviation:  abbreviation,
+		IsDST:         local.IsDST(),
+		ObservesDST:   observesDST(loc, local.Year()),
+		Aliases:       timezoneAliases(entry.Name),
+	}, nil
+}
+
+// 时区是否匹配关键字（名称、国家、说明、中文描述、缩写、别名）
+func timezoneMatchesQuery(item *model.TimezoneItem, query string) bool {
+	query = strings.ToLower(query)
+	fields := []string{
+		item.Name,
+		strings.ReplaceAll(item.Name, "_", " "),
+		item.CountryName,
+		item.CountryNameZh,
+		item.Comment,
+		item.Description,
+		item.Abbreviation,
+		item.Offset,
+	}
+	fields = append(fields, item.Aliases...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return strings.EqualFold(item.CountryCode, query)
+}
+
+// 查询IANA时区列表，可按关键字、国家/地区和当前偏移筛选
+func SearchTimezones(query, country, offset string) (*model.TimezoneListResponse, error) {
+	offsetSeconds := 0
+	if offset != "" {
+		seconds, err := parseUTCOffset(offset)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3011, Message: err.Error()}
+		}
+		offsetSeconds = seconds
+	}
+
+	now := time.Now()
+	items := []model.TimezoneItem{}
+	for _, entry := range IANA_TIMEZONES {
+		item, err := buildTimezoneItem(entry, now)
+		if err != nil {
+			// 系统时区数据缺少该时区时跳过
+			continue
+		}
+		if offset != "" && item.OffsetSeconds != offsetSeconds {
+			continue
+		}
+		if country != "" && !strings.EqualFold(item.CountryCode, country) &&
+			!strings.Contains(strings.ToLower(item.CountryName), strings.ToLower(country)) &&
+			!strings.Contains(item.CountryNameZh, country) {
+			continue
+		}
+		if query != "" && !timezoneMatchesQuery(item, query) {
+			continue
+		}
+		items = append(items, *item)
+	}
+
+	return &model.TimezoneListResponse{
+		Total:     len(items),
+		Version:   IANA_TZDATA_VERSION,
+		Timezones: items,
+	}, nil
+}
+
+// 构建时区时段信息
+func buildTimezonePeriod(t time.Time) model.TimezonePeriod {
+	abbreviation, offset := t.Zone()
+	start, end := t.ZoneBounds()
+	period := model.TimezonePeriod{
+		Abbreviation:  abbreviation,
+		Offset:        formatUTCOffset(offset),
+		OffsetSeconds: offset,
+		IsDST:         t.IsDST(),
+	}
+	if !start.IsZero() {
+		period.Start = start.Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		period.End = end.Format(time.RFC3339)
+	}
+	return period
+}
+
+// 构建时区转换信息，at为转换后时段的开始时间
+func buildTimezoneTransition(at time.Time) model.TimezoneTransition {
+	before := at.Add(-time.Second)
+	fromAbbr, fromOffset := before.Zone()
+	toAbbr, toOffset := at.Zone()
+
+	transitionType := "offset_change"
+	switch {
+	case !before.IsDST() && at.IsDST():
+		transitionType = "dst_start"
+	case before.IsDST() && !at.IsDST():
+		transitionType = "dst_end"
+	}
+
+	var description string
+	diff := toOffset - fromOffset
+	switch {
+	case transitionType == "dst_start":
+		description = "夏令时开始，时钟拨快" + formatDurationChinese(time.Duration(diff)*time.Second)
+	case transitionType == "dst_end":
+		description = "夏令时结束，时钟拨慢" + formatDurationChinese(time.Duration(-diff)*time.Second)
+	case diff > 0:
+		description = "标准时间调整，时钟拨快" + formatDurationChinese(time.Duration(diff)*time.Second)
+	case diff < 0:
+		description = "标准时间调整，时钟拨慢" + formatDurationChinese(time.Duration(-diff)*time.Second)
+	default:
+		description = "时区名称变更"
+	}
+
+	return model.TimezoneTransition{
+		Time:             at.Format(time.RFC3339),
+		UTCTime:          at.UTC().Format(time.RFC3339),
+		Timestamp:        at.Unix(),
+		LocalTimeBefore:  at.In(time.FixedZone(fromAbbr, fromOffset)).Format("2006-01-02 15:04:05"),
+		LocalTimeAfter:   at.Format("2006-01-02 15:04:05"),
+		FromOffset:       formatUTCOffset(fromOffset),
+		ToOffset:         formatUTCOffset(toOffset),
+		FromAbbreviation: fromAbbr,
+		ToAbbreviation:   toAbbr,
+		IsDST:            at.IsDST(),
+		Type:             transitionType,
+		Description:      description,
+	}
+}
+
+// 查询时区的夏令时及偏移转换，year不为0时返回该年全部转换，否则返回最近的过去和将来转换
+func GetTimezoneTransitions(timezone string, year, count int) (*model.TimezoneTransitionsResponse, error) {
+	if timezone == "" {
+		return nil, &model.ErrorResponse{Code: 3011, Message: "timezone参数不能为空"}
+	}
+	if count <= 0 {
+		count = defaultTransitionCount
+	}
+	if count > maxTransitionCount {
+		return nil, &model.ErrorResponse{Code: 3011, Message: fmt.Sprintf("count不能超过%d", maxTransitionCount)}
+	}
+
+	loc, timezoneInfo, err := resolveLocation(timezone, nil)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 3011, Message: err.Error()}
+	}
+
+	now := time.Now().In(loc)
+	response := &model.TimezoneTransitionsResponse{
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+		ObservesDST:  observesDST(loc, now.Year()),
+		Current:      buildTimezonePeriod(now),
+	}
+
+	if year != 0 {
+		if year < 1900 || year > 2100 {
+			return nil, &model.ErrorResponse{Code: 3011, Message: "年份超出支持范围(1900-2100)"}
+		}
+		response.Year = year
+		response.ObservesDST = observesDST(loc, year)
+		response.Transitions = []model.TimezoneTransition{}
+		t := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+		end := time.Date(year+1, 1, 1, 0, 0, 0, 0, loc)
+		for {
+			_, next := t.ZoneBounds()
+			if next.IsZero() || !next.Before(end) {
+				break
+			}
+			response.Transitions = append(response.Transitions, buildTimezoneTransition(next))
+			t = next
+		}
+		return response, nil
+	}
+
+	// 将来的转换
+	response.Upcoming = []model.TimezoneTransition{}
+	t := now
+	for len(response.Upcoming) < count {
+		_, next := t.ZoneBounds()
+		if next.IsZero() {
+			break
+		}
+		response.Upcoming = append(response.Upcoming, buildTimezoneTransition(next))
+		t = next
+	}
+
+	// 过去的转换（按时间先后排列）
+	response.Past = []model.TimezoneTransition{}
+	t = now
+	for len(response.Past) < count {
+		start, _ := t.ZoneBounds()
+		if start.IsZero() {
+			break
+		}
+		response.Past = append([]model.TimezoneTransition{buildTimezoneTransition(start)}, response.Past...)
+		t = start.Add(-time.Second)
+	}
+
+	return response, nil
+}
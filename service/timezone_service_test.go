@@ -0,0 +1,75 @@
+package service
+
+import "testing"
+
+func TestParseUTCOffset(t *testing.T) {
+	cases := []struct {
+		text string
+		want int
+	}{
+		// 小时数，可为小数
+		{"8", 8 * 3600},
+		{"+8", 8 * 3600},
+		{"5.5", 5*3600 + 1800},
+		{"-3.5", -(3*3600 + 1800)},
+		{"5.75", 5*3600 + 2700},
+		// 绝对值大于14按分钟数
+		{"330", 5*3600 + 1800},
+		{"-210", -(3*3600 + 1800)},
+		{"345", 5*3600 + 2700},
+		// +HH:MM
+		{"+05:30", 5*3600 + 1800},
+		{"-03:30", -(3*3600 + 1800)},
+		{"UTC+8", 8 * 3600},
+		{"GMT-3:30", -(3*3600 + 1800)},
+		// +HHMM，以0开头时可省略符号
+		{"+0530", 5*3600 + 1800},
+		{"-0330", -(3*3600 + 1800)},
+		{"0530", 5*3600 + 1800},
+		{"0800", 8 * 3600},
+		{"Z", 0},
+		{"UTC", 0},
+	}
+	for _, c := range cases {
+		got, err := parseUTCOffset(c.text)
+		if err != nil {
+			t.Errorf("parseUTCOffset(%q) error: %v", c.text, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseUTCOffset(%q) = %d, want %d", c.text, got, c.want)
+		}
+	}
+}
+
+func TestParseUTCOffsetInvalid(t *testing.T) {
+	for _, text := range []string{"", "abc", "530", "1000", "14.5", "+05:60", "+15:00", "-13", "+12345"} {
+		if got, err := parseUTCOffset(text); err == nil {
+			t.Errorf("parseUTCOffset(%q) = %d, expected error", text, got)
+		}
+	}
+}
+
+func TestOffsetNumberToSeconds(t *testing.T) {
+	cases := []struct {
+		value float64
+		want  int
+		ok    bool
+	}{
+		{0, 0, true},
+		{14, 14 * 3600, true},
+		{-9.5, -(9*3600 + 1800), true},
+		{5.75, 5*3600 + 2700, true},
+		{-210, -(3*3600 + 1800), true},
+		{720, 12 * 3600, true},
+		{20.5, 0, false}, // 分钟数不能为小数
+		{530, 0, false},  // 不是15的整数倍
+		{100, 0, false},
+	}
+	for _, c := range cases {
+		got, err := offsetNumberToSeconds(c.value)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("offsetNumberToSeconds(%v) = %d, %v, want %d, ok=%v", c.value, got, err, c.want, c.ok)
+		}
+	}
+}
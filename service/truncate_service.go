@@ -0,0 +1,242 @@
+package service
+
+import (
+	"fmt"
+	"github.com/renoz/toolbox-api/model"
+	"github.com/renoz/toolbox-api/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 批量取整单次最多处理的时间数
+const maxTruncateTimes = 1000
+
+// 取整单位允许的倍数需能整除的上级周期，如分钟需整除60，使区间在上级周期内对齐
+var TRUNCATE_UNIT_CYCLES = map[string]int{
+	"second":  60,
+	"minute":  60,
+	"hour":    24,
+	"day":     1,
+	"week":    1,
+	"month":   12,
+	"quarter": 4,
+	"year":    1,
+}
+
+// 时间取整规则
+type truncater struct {
+	unit            string
+	interval        int
+	startWithMonday bool
+}
+
+// 时分秒单位的区间长度
+func (r *truncater) clockDuration() time.Duration {
+	switch r.unit {
+	case "second":
+		return time.Duration(r.interval) * time.Second
+	case "minute":
+		return time.Duration(r.interval) * time.Minute
+	case "hour":
+		return time.Duration(r.interval) * time.Hour
+	default:
+		return 0
+	}
+}
+
+// 区间开始时间：按所在时区的墙上时间对齐
+func (r *truncater) floor(t time.Time) time.Time {
+	// 时分秒按当时的UTC偏移对齐，夏令时切换前后的区间仍为实际时长
+	if d := r.clockDuration(); d > 0 {
+		_, offset := t.Zone()
+		shift := time.Duration(offset) * time.Second
+		return t.Add(shift).Truncate(d).Add(-shift)
+	}
+
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch r.unit {
+	case "day":
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	case "week":
+		offset := int(t.Weekday())
+		if r.startWithMonday {
+			offset = (offset + 6) % 7
+		}
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, loc)
+	case "month":
+		return time.Date(year, month-time.Month((int(month)-1)%r.interval), 1, 0, 0, 0, 0, loc)
+	case "quarter":
+		months := 3 * r.interval
+		return time.Date(year, month-time.Month((int(month)-1)%months), 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+	}
+}
+
+// 下一个区间的开始时间
+func (r *truncater) next(start time.Time) time.Time {
+	if d := r.clockDuration(); d > 0 {
+		return start.Add(d)
+	}
+
+	switch r.unit {
+	case "day":
+		return start.AddDate(0, 0, 1)
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, r.interval, 0)
+	case "quarter":
+		return start.AddDate(0, 3*r.interval, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
+// 按模式取整：floor取区间开始，ceil取区间结束（已对齐时不变），round取较近的一端（正中间时向上）
+func (r *truncater) apply(t time.Time, mode string) (time.Time, time.Time, time.Time) {
+	start := r.floor(t)
+	end := r.next(start)
+	switch mode {
+	case "ceil":
+		if t.Equal(start) {
+			return t, start, end
+		}
+		return end, start, end
+	case "round":
+		if t.Sub(start) >= end.Sub(t) {
+			return end, start, end
+		}
+		return start, start, end
+	default:
+		return start, start, end
+	}
+}
+
+// 时间取整与分桶
+func TruncateTime(req model.TimeTruncateRequest) (*model.TimeTruncateResponse, error) {
+	loc, timezoneInfo, err := resolveLocation(req.Timezone, req.TzOffset)
+	if err != nil {
+		return nil, &model.ErrorResponse{Code: 9000, Message: err.Error()}
+	}
+
+	unit := strings.ToLower(req.Unit)
+	if unit == "" {
+		unit = "minute"
+	}
+	cycle, ok := TRUNCATE_UNIT_CYCLES[unit]
+	if !ok {
+		return nil, &model.ErrorResponse{Code: 3018, Message: "不支持的取整单位: " + req.Unit + "，可选值: second, minute, hour, day, week, month, quarter, year"}
+	}
+	interval := req.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 1 || cycle%interval != 0 {
+		return nil, &model.ErrorResponse{Code: 3018, Message: fmt.Sprintf("%s的倍数必须能整除%d", unit, cycle)}
+	}
+
+	mode := strings.ToLower(req.Mode)
+	if mode == "" {
+		mode = "floor"
+	}
+	if mode != "floor" && mode != "ceil" && mode != "round" {
+		return nil, &model.ErrorResponse{Code: 3018, Message: "不支持的取整方式: " + req.Mode + "，可选值: floor, ceil, round"}
+	}
+
+	if len(req.Times) > maxTruncateTimes {
+		return nil, &model.ErrorResponse{Code: 3018, Message: fmt.Sprintf("times数量不能超过%d", maxTruncateTimes)}
+	}
+
+	rule := &truncater{unit: unit, interval: interval, startWithMonday: true}
+	if req.StartWithMonday != nil {
+		rule.startWithMonday = *req.StartWithMonday
+	}
+
+	format := req.OutputFormat
+	if format == "" {
+		format = "iso"
+	}
+	if err := checkOutputFormat(format); err != nil {
+		return nil, &model.ErrorResponse{Code: 3018, Message: err.Error()}
+	}
+
+	// 单个时间取整，结果附带所在区间
+	truncate := func(input interface{}) (model.TimeTruncateItem, time.Time, error) {
+		t, original, err := parseTimeInput(input, loc)
+		if err != nil {
+			return model.TimeTruncateItem{}, time.Time{}, err
+		}
+		t = t.In(loc)
+		result, start, end := rule.apply(t, mode)
+		item := model.TimeTruncateItem{
+			Original:    original,
+			Result:      formatTime(result, format, req.CustomFormat),
+			Timestamp:   result.Unix(),
+			BucketStart: formatTime(start, format, req.CustomFormat),
+			BucketEnd:   formatTime(end, format, req.CustomFormat),
+		}
+		if unit == "week" {
+			item.WeekNumber = utils.GetWeekNumberInYear(start, rule.startWithMonday)
+		}
+		return item, start, nil
+	}
+
+	response := &model.TimeTruncateResponse{
+		Unit:         unit,
+		Interval:     interval,
+		Mode:         mode,
+		Timezone:     timezoneInfo.Name,
+		TimezoneInfo: *timezoneInfo,
+	}
+
+	if req.TimeInput != nil || len(req.Times) == 0 {
+		input := req.TimeInput
+		if input == nil {
+			input = time.Now().Unix()
+		}
+		item, _, err := truncate(input)
+		if err != nil {
+			return nil, &model.ErrorResponse{Code: 3018, Message: err.Error()}
+		}
+		response.Result = &item
+	}
+
+	// 批量取整，按区间开始时间分桶
+	if len(req.Times) > 0 {
+		buckets := map[int64]*model.TimeBucket{}
+		response.Results = make([]model.TimeTruncateItem, 0, len(req.Times))
+		for i, input := range req.Times {
+			item, start, err := truncate(input)
+			if err != nil {
+				return nil, &model.ErrorResponse{Code: 3018, Message: fmt.Sprintf("第%d个时间%s", i+1, err.Error())}
+			}
+			response.Results = append(response.Results, item)
+
+			bucket, ok := buckets[start.Unix()]
+			if !ok {
+				bucket = &model.TimeBucket{
+					Start:     item.BucketStart,
+					End:       item.BucketEnd,
+					Timestamp: start.Unix(),
+					Items:     []string{},
+				}
+				buckets[start.Unix()] = bucket
+			}
+			bucket.Count++
+			bucket.Items = append(bucket.Items, item.Original)
+		}
+
+		response.Buckets = make([]model.TimeBucket, 0, len(buckets))
+		for _, bucket := range buckets {
+			response.Buckets = append(response.Buckets, *bucket)
+		}
+		sort.Slice(response.Buckets, func(i, j int) bool {
+			return response.Buckets[i].Timestamp < response.Buckets[j].Timestamp
+		})
+	}
+
+	return response, nil
+}
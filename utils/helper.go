@@ -125,6 +125,11 @@ func ParseDate(dateStr string) (time.Time, error) {
 
 // 解析日期时间字符串
 func ParseDateTime(datetimeStr string) (time.Time, error) {
+	return ParseDateTimeInLocation(datetimeStr, time.UTC)
+}
+
+// 在指定时区解析日期时间字符串（带时区偏移的格式以字符串中的偏移为准）
+func ParseDateTimeInLocation(datetimeStr string, loc *time.Location) (time.Time, error) {
 	// 尝试多种常见日期时间格式
 	formats := []string{
 		"2006-01-02 15:04:05",
@@ -136,7 +141,7 @@ func ParseDateTime(datetimeStr string) (time.Time, error) {
 	
 	var parseErr error
 	for _, format := range formats {
-		t, err := time.Parse(format, datetimeStr)
+		t, err := time.ParseInLocation(format, datetimeStr, loc)
 		if err == nil {
 			return t, nil
 		}